@@ -1,15 +1,49 @@
 package config
 
 import (
+	"github.com/joho/godotenv"
 	"log"
 	"os"
-	"github.com/joho/godotenv"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	MongoURI      string
-	DatabaseName  string
-	ServerPort    string
+	MongoURI         string
+	DatabaseName     string
+	ServerPort       string
+	JWTSecret        string
+	JWTPublicKeyFile string
+	JWKSFile         string
+	JWTIssuer        string
+	JWTAudience      string
+
+	KafkaBrokers       []string
+	KafkaTopic         string
+	KafkaRequiredAcks  string // all, one or none
+	KafkaBatchSize     int
+	KafkaBatchTimeout  time.Duration
+	KafkaCompression   string // none, gzip, snappy, lz4 or zstd
+	KafkaTLS           bool
+	KafkaSASLMechanism string // empty, plain, scram-sha-256 or scram-sha-512
+	KafkaSASLUsername  string
+	KafkaSASLPassword  string
+	KafkaEventEncoding string // json or protobuf
+
+	// Upstream topics consumed by the service; an empty topic is not consumed.
+	KafkaConsumerGroup       string
+	KafkaUserDeletedTopic    string
+	KafkaJobClosedTopic      string
+	KafkaContractSignedTopic string
+	KafkaConsumerMaxAttempts int // tries per message before it is skipped; 0 retries forever
+
+	AttachmentStore        string // gridfs or local
+	AttachmentDir          string // used by the local store
+	AttachmentMaxBytes     int64
+	AttachmentAllowedTypes []string
+
+	ListMaxPageSize int64 // largest page ListProposals returns
 }
 
 func LoadConfig() *Config {
@@ -25,17 +59,141 @@ func LoadConfig() *Config {
 
 	databaseName := os.Getenv("MONGO_DB")
 	if databaseName == "" {
-		databaseName = "freelanceX_proposals" 
+		databaseName = "freelanceX_proposals"
 	}
 
 	serverPort := os.Getenv("SERVER_PORT")
 	if serverPort == "" {
-		serverPort = ":50052" 
+		serverPort = ":50052"
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	jwtPublicKeyFile := os.Getenv("JWT_PUBLIC_KEY_FILE")
+	jwksFile := os.Getenv("JWKS_FILE")
+	if jwtSecret == "" && jwtPublicKeyFile == "" && jwksFile == "" {
+		log.Fatal("one of JWT_SECRET, JWT_PUBLIC_KEY_FILE or JWKS_FILE is required but none is set")
+	}
+
+	kafkaBrokers := strings.Split(getEnv("KAFKA_BROKERS", "kafka:9092"), ",")
+	for i := range kafkaBrokers {
+		kafkaBrokers[i] = strings.TrimSpace(kafkaBrokers[i])
+	}
+
+	listMaxPageSize := getEnvInt("LIST_MAX_PAGE_SIZE", 500)
+	if listMaxPageSize < 1 {
+		log.Fatal("LIST_MAX_PAGE_SIZE must be at least 1")
 	}
 
 	return &Config{
-		MongoURI:     mongoURI,
-		DatabaseName: databaseName,
-		ServerPort:   serverPort,
+		MongoURI:         mongoURI,
+		DatabaseName:     databaseName,
+		ServerPort:       serverPort,
+		JWTSecret:        jwtSecret,
+		JWTPublicKeyFile: jwtPublicKeyFile,
+		JWKSFile:         jwksFile,
+		JWTIssuer:        os.Getenv("JWT_ISSUER"),
+		JWTAudience:      os.Getenv("JWT_AUDIENCE"),
+
+		KafkaBrokers:       kafkaBrokers,
+		KafkaTopic:         getEnv("KAFKA_TOPIC", "proposal-events"),
+		KafkaRequiredAcks:  getEnv("KAFKA_REQUIRED_ACKS", "all"),
+		KafkaBatchSize:     getEnvInt("KAFKA_BATCH_SIZE", 100),
+		KafkaBatchTimeout:  getEnvDuration("KAFKA_BATCH_TIMEOUT", 10*time.Millisecond),
+		KafkaCompression:   getEnv("KAFKA_COMPRESSION", "none"),
+		KafkaTLS:           getEnvBool("KAFKA_TLS", false),
+		KafkaSASLMechanism: os.Getenv("KAFKA_SASL_MECHANISM"),
+		KafkaSASLUsername:  os.Getenv("KAFKA_SASL_USERNAME"),
+		KafkaSASLPassword:  os.Getenv("KAFKA_SASL_PASSWORD"),
+		KafkaEventEncoding: getEnv("KAFKA_EVENT_ENCODING", "json"),
+
+		KafkaConsumerGroup:       getEnv("KAFKA_CONSUMER_GROUP", "proposal-service"),
+		KafkaUserDeletedTopic:    lookupEnv("KAFKA_USER_DELETED_TOPIC", "user.deleted"),
+		KafkaJobClosedTopic:      lookupEnv("KAFKA_JOB_CLOSED_TOPIC", "job.closed"),
+		KafkaContractSignedTopic: lookupEnv("KAFKA_CONTRACT_SIGNED_TOPIC", "contract.signed"),
+		KafkaConsumerMaxAttempts: getEnvInt("KAFKA_CONSUMER_MAX_ATTEMPTS", 10),
+
+		AttachmentStore:        getEnv("ATTACHMENT_STORE", "gridfs"),
+		AttachmentDir:          getEnv("ATTACHMENT_DIR", "attachments"),
+		AttachmentMaxBytes:     int64(getEnvInt("ATTACHMENT_MAX_BYTES", 10<<20)),
+		AttachmentAllowedTypes: getEnvList("ATTACHMENT_ALLOWED_TYPES", defaultAttachmentTypes),
+
+		ListMaxPageSize: int64(listMaxPageSize),
+	}
+}
+
+var defaultAttachmentTypes = []string{
+	"application/pdf",
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"text/plain",
+	"application/zip",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+func getEnvList(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, strings.ToLower(item))
+		}
+	}
+	return list
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// lookupEnv is like getEnv but honours a variable explicitly set to empty.
+func lookupEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("%s must be an integer: %v", key, err)
+	}
+	return n
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("%s must be a boolean: %v", key, err)
+	}
+	return b
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("%s must be a duration such as 500ms: %v", key, err)
 	}
+	return d
 }
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenRoles are the roles a token may claim. The internal "system" role is
+// deliberately absent so no caller can impersonate the service itself.
+var tokenRoles = map[string]bool{
+	"freelancer": true,
+	"client":     true,
+	"admin":      true,
+}
+
+type Authenticator struct {
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+// NewAuthenticator verifies tokens against keys. Issuer and audience are only
+// checked when non-empty.
+func NewAuthenticator(keys *KeySet, issuer, audience string) *Authenticator {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	return &Authenticator{keys: keys, parser: jwt.NewParser(opts...)}
+}
+
+// Authenticate verifies the bearer token in the incoming metadata and returns
+// a context carrying its claims.
+func (a *Authenticator) Authenticate(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+	raw, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	claims := &Claims{}
+	if _, err := a.parser.ParseWithClaims(strings.TrimSpace(raw), claims, a.keys.keyFunc); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	if claims.UserID == "" {
+		claims.UserID = claims.Subject
+	}
+	claims.Role = strings.ToLower(claims.Role)
+	if claims.UserID == "" {
+		return nil, status.Error(codes.Unauthenticated, "token has no user id")
+	}
+	if !tokenRoles[claims.Role] {
+		return nil, status.Errorf(codes.PermissionDenied, "token role %q is not allowed", claims.Role)
+	}
+
+	return NewContext(ctx, claims), nil
+}
+
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.Authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.Authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
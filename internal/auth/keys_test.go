@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func writePEM(t *testing.T, key *rsa.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "public.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+	set := map[string]interface{}{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// bearer returns an incoming context carrying an RS256 token signed by key.
+func bearer(t *testing.T, key *rsa.PrivateKey, kid string) context.Context {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, Claims{
+		UserID: "user-1",
+		Role:   "freelancer",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signed))
+}
+
+func TestRS256KeySelection(t *testing.T) {
+	pemKey := newRSAKey(t)
+	jwksKey := newRSAKey(t)
+	otherKey := newRSAKey(t)
+
+	both, err := LoadKeySet("", writePEM(t, &pemKey.PublicKey), writeJWKS(t, "current", &jwksKey.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwksOnly, err := LoadKeySet("", "", writeJWKS(t, "current", &jwksKey.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		keys *KeySet
+		key  *rsa.PrivateKey
+		kid  string
+		ok   bool
+	}{
+		{"kid in the JWKS", both, jwksKey, "current", true},
+		{"kid unknown, signed with the PEM key", both, pemKey, "legacy", true},
+		{"no kid, signed with the PEM key", both, pemKey, "", true},
+		{"kid unknown, signed with another key", both, otherKey, "legacy", false},
+		{"kid in the JWKS, signed with the PEM key", both, pemKey, "current", false},
+		{"kid unknown without a PEM key", jwksOnly, jwksKey, "legacy", false},
+		{"no kid with a single JWKS key", jwksOnly, jwksKey, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthenticator(tt.keys, "", "").Authenticate(bearer(t, tt.key, tt.kid))
+			if (err == nil) != tt.ok {
+				t.Errorf("Authenticate error = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package diff
+
+import "strings"
+
+type Op string
+
+const (
+	OpAdded   Op = "added"
+	OpRemoved Op = "removed"
+)
+
+// Line is a single added or removed line. FromLine and ToLine are 1-based
+// positions in the old and new text; the side the line does not exist on
+// holds the position it would have been at.
+type Line struct {
+	Op       Op
+	Text     string
+	FromLine int
+	ToLine   int
+}
+
+// maxLCSCells bounds the LCS table; larger inputs are reported as a full
+// replacement of the differing middle instead of a minimal diff.
+const maxLCSCells = 4_000_000
+
+// Lines returns the line-level changes needed to turn a into b.
+func Lines(a, b string) []Line {
+	if a == b {
+		return nil
+	}
+	from := splitLines(a)
+	to := splitLines(b)
+
+	// Trim the common prefix and suffix so the table only covers the edit.
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	x := from[prefix : len(from)-suffix]
+	y := to[prefix : len(to)-suffix]
+
+	var changes []Line
+	if len(x)*len(y) > maxLCSCells {
+		for i, line := range x {
+			changes = append(changes, Line{Op: OpRemoved, Text: line, FromLine: prefix + i + 1, ToLine: prefix + 1})
+		}
+		for j, line := range y {
+			changes = append(changes, Line{Op: OpAdded, Text: line, FromLine: prefix + len(x) + 1, ToLine: prefix + j + 1})
+		}
+		return changes
+	}
+
+	// lcs[i][j] is the LCS length of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			i++
+			j++
+		case j < len(y) && (i == len(x) || lcs[i][j+1] > lcs[i+1][j]):
+			changes = append(changes, Line{Op: OpAdded, Text: y[j], FromLine: prefix + i + 1, ToLine: prefix + j + 1})
+			j++
+		default:
+			changes = append(changes, Line{Op: OpRemoved, Text: x[i], FromLine: prefix + i + 1, ToLine: prefix + j + 1})
+			i++
+		}
+	}
+	return changes
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,145 @@
+package diff
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+)
+
+const (
+	SectionAdded   = "added"
+	SectionRemoved = "removed"
+	SectionChanged = "changed"
+)
+
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+type SectionChange struct {
+	SectionID string
+	Heading   string
+	// FromHeading is set when the section was renamed.
+	FromHeading string
+	Change      string
+	Lines       []Line
+}
+
+type ProposalDiff struct {
+	FromVersion int
+	ToVersion   int
+	Fields      []FieldChange
+	Sections    []SectionChange
+	Content     []Line
+}
+
+// Proposals compares two versions of the same proposal. Sections are matched
+// by id, and sections that cannot be matched that way by heading, repeated
+// headings pairing up in order of appearance.
+func Proposals(from, to *model.ProposalRevision) ProposalDiff {
+	d := ProposalDiff{
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+	}
+
+	if from.Title != to.Title {
+		d.Fields = append(d.Fields, FieldChange{Field: "title", From: from.Title, To: to.Title})
+	}
+	if !from.Deadline.Equal(to.Deadline) {
+		d.Fields = append(d.Fields, FieldChange{Field: "deadline", From: formatTime(from.Deadline), To: formatTime(to.Deadline)})
+	}
+	if from.Status != to.Status {
+		d.Fields = append(d.Fields, FieldChange{Field: "status", From: from.Status, To: to.Status})
+	}
+	if fromTotal, toTotal := formatTotal(from.Pricing), formatTotal(to.Pricing); fromTotal != toTotal {
+		d.Fields = append(d.Fields, FieldChange{Field: "pricing.total", From: fromTotal, To: toTotal})
+	}
+
+	d.Sections = sectionChanges(from.Sections, to.Sections)
+	d.Content = Lines(from.Content, to.Content)
+	return d
+}
+
+func sectionChanges(from, to []model.Section) []SectionChange {
+	// pair[i] is the index in to of the section matching from[i], or -1.
+	pair := make([]int, len(from))
+	matched := make([]bool, len(to))
+	toByID := make(map[string]int, len(to))
+	for j, sec := range to {
+		if sec.ID != "" {
+			toByID[sec.ID] = j
+		}
+	}
+	for i, sec := range from {
+		pair[i] = -1
+		if j, ok := toByID[sec.ID]; ok && sec.ID != "" {
+			pair[i] = j
+			matched[j] = true
+		}
+	}
+
+	type key struct {
+		heading string
+		nth     int
+	}
+	toByKey := make(map[key]int)
+	seen := make(map[string]int)
+	for j, sec := range to {
+		if matched[j] {
+			continue
+		}
+		toByKey[key{heading: sec.Heading, nth: seen[sec.Heading]}] = j
+		seen[sec.Heading]++
+	}
+	seen = make(map[string]int)
+	for i, sec := range from {
+		if pair[i] >= 0 {
+			continue
+		}
+		k := key{heading: sec.Heading, nth: seen[sec.Heading]}
+		seen[sec.Heading]++
+		if j, ok := toByKey[k]; ok {
+			pair[i] = j
+			matched[j] = true
+		}
+	}
+
+	var changes []SectionChange
+	for i, old := range from {
+		if pair[i] < 0 {
+			changes = append(changes, SectionChange{SectionID: old.ID, Heading: old.Heading, Change: SectionRemoved, Lines: Lines(old.Body, "")})
+			continue
+		}
+		cur := to[pair[i]]
+		change := SectionChange{SectionID: cur.ID, Heading: cur.Heading, Change: SectionChanged, Lines: Lines(old.Body, cur.Body)}
+		if old.Heading != cur.Heading {
+			change.FromHeading = old.Heading
+		}
+		if len(change.Lines) > 0 || change.FromHeading != "" {
+			changes = append(changes, change)
+		}
+	}
+	for j, cur := range to {
+		if !matched[j] {
+			changes = append(changes, SectionChange{SectionID: cur.ID, Heading: cur.Heading, Change: SectionAdded, Lines: Lines("", cur.Body)})
+		}
+	}
+	return changes
+}
+
+func formatTotal(p *model.Pricing) string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %d.%02d", p.Currency, p.TotalCents/100, p.TotalCents%100)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
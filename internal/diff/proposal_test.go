@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+)
+
+func revision(version int, sections ...model.Section) *model.ProposalRevision {
+	return &model.ProposalRevision{
+		Version:  version,
+		Title:    "Logo design",
+		Content:  model.ContentFromSections(sections),
+		Status:   model.StatusDraft,
+		Sections: sections,
+	}
+}
+
+var (
+	scope    = model.Section{ID: "s1", Heading: "Scope", Body: "Three concepts\nTwo revisions", Order: 1}
+	timeline = model.Section{ID: "s2", Heading: "Timeline", Body: "Two weeks", Order: 2}
+	terms    = model.Section{ID: "s3", Heading: "Terms", Body: "Net 30", Order: 3}
+)
+
+func TestProposalsIdenticalVersions(t *testing.T) {
+	from := revision(1, scope, timeline, terms)
+	to := revision(2, scope, timeline, terms)
+
+	d := Proposals(from, to)
+	if d.FromVersion != 1 || d.ToVersion != 2 {
+		t.Errorf("versions = %d..%d, want 1..2", d.FromVersion, d.ToVersion)
+	}
+	if len(d.Fields) != 0 || len(d.Sections) != 0 || len(d.Content) != 0 {
+		t.Errorf("diff of identical versions = %+v, want no changes", d)
+	}
+}
+
+func TestProposalsSections(t *testing.T) {
+	renamed := timeline
+	renamed.Heading = "Schedule"
+	edited := scope
+	edited.Body = "Three concepts\nThree revisions"
+	moved := func(sec model.Section, order int) model.Section {
+		sec.Order = order
+		return sec
+	}
+	budget := model.Section{ID: "s4", Heading: "Budget", Body: "$1,000", Order: 4}
+
+	tests := []struct {
+		name string
+		from []model.Section
+		to   []model.Section
+		want []SectionChange
+	}{
+		{
+			name: "added",
+			from: []model.Section{scope, timeline},
+			to:   []model.Section{scope, timeline, budget},
+			want: []SectionChange{{SectionID: "s4", Heading: "Budget", Change: SectionAdded, Lines: []Line{{Op: OpAdded, Text: "$1,000", FromLine: 1, ToLine: 1}}}},
+		},
+		{
+			name: "removed",
+			from: []model.Section{scope, timeline, terms},
+			to:   []model.Section{scope, timeline},
+			want: []SectionChange{{SectionID: "s3", Heading: "Terms", Change: SectionRemoved, Lines: []Line{{Op: OpRemoved, Text: "Net 30", FromLine: 1, ToLine: 1}}}},
+		},
+		{
+			name: "reordered",
+			from: []model.Section{scope, timeline, terms},
+			to:   []model.Section{moved(terms, 1), moved(scope, 2), moved(timeline, 3)},
+			want: nil,
+		},
+		{
+			name: "edited",
+			from: []model.Section{scope, timeline},
+			to:   []model.Section{edited, timeline},
+			want: []SectionChange{{SectionID: "s1", Heading: "Scope", Change: SectionChanged, Lines: []Line{
+				{Op: OpRemoved, Text: "Two revisions", FromLine: 2, ToLine: 2},
+				{Op: OpAdded, Text: "Three revisions", FromLine: 3, ToLine: 2},
+			}}},
+		},
+		{
+			name: "renamed",
+			from: []model.Section{scope, timeline},
+			to:   []model.Section{scope, renamed},
+			want: []SectionChange{{SectionID: "s2", Heading: "Schedule", FromHeading: "Timeline", Change: SectionChanged}},
+		},
+		{
+			name: "matched by heading without ids",
+			from: []model.Section{{Heading: "Scope", Body: "One"}, {Heading: "Scope", Body: "Two"}},
+			to:   []model.Section{{Heading: "Scope", Body: "One"}, {Heading: "Scope", Body: "Deux"}},
+			want: []SectionChange{{Heading: "Scope", Change: SectionChanged, Lines: []Line{
+				{Op: OpRemoved, Text: "Two", FromLine: 1, ToLine: 1},
+				{Op: OpAdded, Text: "Deux", FromLine: 2, ToLine: 1},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Proposals(revision(1, tt.from...), revision(2, tt.to...))
+			if !reflect.DeepEqual(d.Sections, tt.want) {
+				t.Errorf("sections = %+v\nwant %+v", d.Sections, tt.want)
+			}
+		})
+	}
+}
+
+func TestProposalsFields(t *testing.T) {
+	from := revision(1, scope)
+	to := revision(2, scope)
+	to.Title = "Logo and brand guide"
+	to.Status = model.StatusSent
+	to.Deadline = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to.Pricing = &model.Pricing{Currency: "USD", TotalCents: 150_050}
+
+	want := []FieldChange{
+		{Field: "title", From: "Logo design", To: "Logo and brand guide"},
+		{Field: "deadline", From: "", To: "2026-05-01T00:00:00Z"},
+		{Field: "status", From: model.StatusDraft, To: model.StatusSent},
+		{Field: "pricing.total", From: "", To: "USD 1500.50"},
+	}
+	if d := Proposals(from, to); !reflect.DeepEqual(d.Fields, want) {
+		t.Errorf("fields = %+v\nwant %+v", d.Fields, want)
+	}
+}
@@ -0,0 +1,172 @@
+// Package export renders proposals as documents a client can download.
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatPDF      = "pdf"
+)
+
+// DefaultAccentColor is used when the freelancer has not chosen one.
+const DefaultAccentColor = "#1F4E79"
+
+// Branding is the look of an exported proposal.
+type Branding struct {
+	AccentColor string // #RRGGBB
+	Footer      string
+	Logo        []byte
+	LogoType    string // image/png or image/jpeg
+}
+
+func (b Branding) accent() string {
+	if b.AccentColor == "" {
+		return DefaultAccentColor
+	}
+	return b.AccentColor
+}
+
+// Render writes p to w in the given format.
+func Render(w io.Writer, format string, p *model.Proposal, b Branding) error {
+	switch format {
+	case FormatMarkdown:
+		return renderMarkdown(w, p, b)
+	case FormatHTML:
+		return renderHTML(w, p, b)
+	case FormatPDF:
+		return renderPDF(w, p, b)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// ContentType is the MIME type of documents in the given format.
+func ContentType(format string) string {
+	switch format {
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return ""
+}
+
+// Formats lists the supported export formats.
+func Formats() []string {
+	return []string{FormatMarkdown, FormatHTML, FormatPDF}
+}
+
+var fileNameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// FileName suggests a download name for p in the given format.
+func FileName(p *model.Proposal, format string) string {
+	name := strings.Trim(fileNameUnsafe.ReplaceAllString(strings.ToLower(p.Title), "-"), "-")
+	if len(name) > 80 {
+		name = strings.TrimRight(name[:80], "-")
+	}
+	if name == "" {
+		name = "proposal-" + p.ID.Hex()
+	}
+	ext := map[string]string{FormatMarkdown: ".md", FormatHTML: ".html", FormatPDF: ".pdf"}[format]
+	return name + ext
+}
+
+// sections returns what the document body is made of: the proposal sections,
+// or its free-text content under a single heading.
+func sections(p *model.Proposal) []model.Section {
+	if len(p.Sections) > 0 || strings.TrimSpace(p.Content) == "" {
+		return p.Sections
+	}
+	return []model.Section{{Heading: "Proposal", Body: p.Content, Format: p.ContentFormat}}
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("January 2, 2006")
+}
+
+// formatMoney writes minor units as a decimal amount with thousands
+// separators, e.g. "USD 1,234.50".
+func formatMoney(cents int64, currency string) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	units := fmt.Sprintf("%d", cents/100)
+	var grouped strings.Builder
+	for i, digit := range units {
+		if i > 0 && (len(units)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return fmt.Sprintf("%s %s%s.%02d", currency, sign, grouped.String(), cents%100)
+}
+
+func formatPercent(bps int64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", float64(bps)/100), "0"), ".") + "%"
+}
+
+// pricingRow is one line of the pricing table shared by all formats.
+type pricingRow struct {
+	Label  string
+	Amount string
+	Total  bool
+}
+
+func pricingRows(pr *model.Pricing) []pricingRow {
+	var rows []pricingRow
+	if pr.Type == model.PricingHourly {
+		rows = append(rows, pricingRow{
+			Label:  fmt.Sprintf("%g hours at %s per hour", pr.EstimatedHours, formatMoney(pr.HourlyRateCents, pr.Currency)),
+			Amount: formatMoney(pr.BaseCents(), pr.Currency),
+		})
+	} else {
+		rows = append(rows, pricingRow{Label: "Fixed price", Amount: formatMoney(pr.FixedPriceCents, pr.Currency)})
+	}
+	for _, item := range pr.LineItems {
+		label := item.Description
+		if item.Quantity != 1 {
+			label = fmt.Sprintf("%s (%d x %s)", item.Description, item.Quantity, formatMoney(item.UnitPriceCents, pr.Currency))
+		}
+		rows = append(rows, pricingRow{Label: label, Amount: formatMoney(item.AmountCents, pr.Currency)})
+	}
+	rows = append(rows, pricingRow{Label: "Subtotal", Amount: formatMoney(pr.SubtotalCents, pr.Currency)})
+	for _, d := range pr.Discounts {
+		rows = append(rows, pricingRow{Label: adjustmentLabel(d), Amount: formatMoney(-d.AppliedCents, pr.Currency)})
+	}
+	for _, t := range pr.Taxes {
+		rows = append(rows, pricingRow{Label: adjustmentLabel(t), Amount: formatMoney(t.AppliedCents, pr.Currency)})
+	}
+	return append(rows, pricingRow{Label: "Total", Amount: formatMoney(pr.TotalCents, pr.Currency), Total: true})
+}
+
+func adjustmentLabel(a model.Adjustment) string {
+	if a.PercentBps != 0 {
+		return fmt.Sprintf("%s (%s)", a.Name, formatPercent(a.PercentBps))
+	}
+	return a.Name
+}
+
+// paragraphs splits text on blank lines, keeping the line breaks inside each
+// paragraph.
+func paragraphs(text string) [][]string {
+	var result [][]string
+	for _, block := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if block = strings.TrimSpace(block); block != "" {
+			result = append(result, strings.Split(block, "\n"))
+		}
+	}
+	return result
+}
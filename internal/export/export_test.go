@@ -0,0 +1,174 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func render(t *testing.T, format string, p *model.Proposal, b Branding) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Render(&buf, format, p, b); err != nil {
+		t.Fatalf("Render(%s): %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestMarkdownText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Website redesign", "Website redesign"},
+		{"# Not a heading", `\# Not a heading`},
+		{"*bold* and _em_", `\*bold\* and \_em\_`},
+		{"[link](javascript:alert(1))", `\[link\](javascript:alert(1))`},
+		{"![img](x.png)", `\!\[img\](x.png)`},
+		{"<script>alert(1)</script>", `\<script\>alert(1)\</script\>`},
+		{"a | b", `a \| b`},
+		{"`code` ~strike~", "\\`code\\` \\~strike\\~"},
+		{`C:\temp &amp;`, `C:\\temp \&amp;`},
+		{"- not a bullet", `\- not a bullet`},
+		{"+ nor this", `\+ nor this`},
+		{"=== nor this", `\=== nor this`},
+		{"1. not a list", `1\. not a list`},
+		{"2) nor this", `2\) nor this`},
+		{"Costs 1.5 - 2.5 days", "Costs 1.5 - 2.5 days"},
+	}
+	for _, tt := range tests {
+		if got := markdownText(tt.in); got != tt.want {
+			t.Errorf("markdownText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownBody(t *testing.T) {
+	tests := []struct {
+		name, format, body, want string
+	}{
+		{"markdown is copied", richtext.FormatMarkdown, "  **Scope**\n\n- one\n- two\n", "**Scope**\n\n- one\n- two"},
+		{"default format is markdown", "", "# Title", "# Title"},
+		{"plain text is escaped", richtext.FormatPlain, "*Not bold*\n# not a heading", `\*Not bold\*` + "\\\n" + `\# not a heading`},
+		{"plain paragraphs are kept", richtext.FormatPlain, "One\n\n\nTwo", "One\n\nTwo"},
+		{"html is reduced to text", richtext.FormatHTML, "<p>Hello <b>world</b></p><p>1. *a*</p>", "Hello world\\\n" + `1\. \*a\*`},
+		{"html entities are decoded", richtext.FormatHTML, "<p>Fish &amp; chips</p>", `Fish \& chips`},
+		{"empty", richtext.FormatPlain, " \n ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownBody(tt.format, tt.body); got != tt.want {
+				t.Errorf("markdownBody = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownEscapesText(t *testing.T) {
+	p := &model.Proposal{
+		ID:       primitive.NewObjectID(),
+		Title:    "Logo *and*\n# brand",
+		Deadline: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+		Sections: []model.Section{
+			{Heading: "[Scope](x)", Body: "Three **concepts**", Format: richtext.FormatMarkdown},
+			{Heading: "Terms", Body: "<p>Pay <i>net</i> 30</p>", Format: richtext.FormatHTML},
+		},
+		Pricing: &model.Pricing{
+			Currency:        "USD",
+			Type:            model.PricingFixed,
+			FixedPriceCents: 100_000,
+			LineItems:       []model.LineItem{{Description: "Fonts | licences", Quantity: 1, UnitPriceCents: 5_000}},
+		},
+	}
+	p.Pricing.ComputeTotals()
+
+	got := render(t, FormatMarkdown, p, Branding{Footer: "_Studio_ Ltd"})
+	for _, want := range []string{
+		"# Logo \\*and\\* \\# brand\n",
+		"**Deadline:** December 1, 2026\n",
+		"## \\[Scope\\](x)\n\nThree **concepts**\n",
+		"## Terms\n\nPay net 30\n",
+		"| Fonts \\| licences | USD 50.00 |\n",
+		"| **Total** | **USD 1,050.00** |\n",
+		"---\n\n\\_Studio\\_ Ltd\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown export lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderUsesContentFormatWithoutSections(t *testing.T) {
+	p := &model.Proposal{
+		ID:            primitive.NewObjectID(),
+		Title:         "Logo",
+		Content:       "<p>Hello</p><script>alert(1)</script>",
+		ContentFormat: richtext.FormatHTML,
+	}
+
+	if got := render(t, FormatMarkdown, p, Branding{}); !strings.Contains(got, "## Proposal\n\nHello\n") || strings.Contains(got, "<p>") {
+		t.Errorf("markdown export does not convert the html content:\n%s", got)
+	}
+	got := render(t, FormatHTML, p, Branding{})
+	if !strings.Contains(got, "<p>Hello</p>") || strings.Contains(got, "<script>") {
+		t.Errorf("html export does not render the sanitized content:\n%s", got)
+	}
+}
+
+func TestRenderHTMLEscapesText(t *testing.T) {
+	p := &model.Proposal{
+		ID:       primitive.NewObjectID(),
+		Title:    "<b>Logo</b>",
+		Sections: []model.Section{{Heading: "<i>Scope</i>", Body: "5 < 6", Format: richtext.FormatPlain}},
+	}
+
+	got := render(t, FormatHTML, p, Branding{AccentColor: "red;}</style><script>", Footer: "<img src=x>"})
+	for _, unwanted := range []string{"<b>Logo</b>", "<i>Scope</i>", "<script>", "<img"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("html export contains %q:\n%s", unwanted, got)
+		}
+	}
+	for _, want := range []string{"&lt;b&gt;Logo&lt;/b&gt;", "<p>5 &lt; 6</p>", DefaultAccentColor} {
+		if !strings.Contains(got, want) {
+			t.Errorf("html export lacks %q", want)
+		}
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	p := &model.Proposal{
+		ID:       primitive.NewObjectID(),
+		Title:    "Logo",
+		Sections: []model.Section{{Heading: "Scope", Body: "<p>Three concepts</p>", Format: richtext.FormatHTML}},
+	}
+	if got := render(t, FormatPDF, p, Branding{Footer: "Studio"}); !strings.HasPrefix(got, "%PDF-") {
+		t.Errorf("pdf export starts with %q", got[:min(len(got), 8)])
+	}
+}
+
+func TestRenderRejectsUnknownFormat(t *testing.T) {
+	if err := Render(&bytes.Buffer{}, "docx", &model.Proposal{}, Branding{}); err == nil {
+		t.Error("Render accepted an unknown format")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	id := primitive.NewObjectID()
+	tests := []struct {
+		title, format, want string
+	}{
+		{"Website Redesign (v2)", FormatPDF, "website-redesign-v2.pdf"},
+		{"../../etc/passwd", FormatMarkdown, "etc-passwd.md"},
+		{"   ", FormatHTML, "proposal-" + id.Hex() + ".html"},
+		{strings.Repeat("ab ", 40), FormatPDF, strings.TrimRight(strings.Repeat("ab-", 27), "-") + ".pdf"},
+	}
+	for _, tt := range tests {
+		if got := FileName(&model.Proposal{ID: id, Title: tt.title}, tt.format); got != tt.want {
+			t.Errorf("FileName(%q, %s) = %q, want %q", tt.title, tt.format, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"encoding/base64"
+	"html/template"
+	"io"
+	"regexp"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+)
+
+var accentColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// ValidAccentColor reports whether c is a #RRGGBB color.
+func ValidAccentColor(c string) bool {
+	return accentColor.MatchString(c)
+}
+
+var htmlPage = template.Must(template.New("proposal").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; color: #222; max-width: 48em; margin: 2em auto; padding: 0 1em; line-height: 1.5; }
+h1, h2 { color: {{.Accent}}; }
+h1 { border-bottom: 3px solid {{.Accent}}; padding-bottom: .3em; }
+.logo { max-height: 80px; float: right; }
+.deadline { color: #555; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: .4em .6em; border-bottom: 1px solid #ddd; }
+td.amount, th.amount { text-align: right; white-space: nowrap; }
+tr.total td { font-weight: bold; border-top: 2px solid {{.Accent}}; }
+footer { margin-top: 3em; padding-top: 1em; border-top: 1px solid #ddd; color: #555; font-size: .9em; }
+</style>
+</head>
+<body>
+{{if .Logo}}<img class="logo" src="{{.Logo}}" alt="Logo">
+{{end}}<h1>{{.Title}}</h1>
+{{if .Deadline}}<p class="deadline"><strong>Deadline:</strong> {{.Deadline}}</p>
+{{end}}{{range .Sections}}<section>
+<h2>{{.Heading}}</h2>
+{{.Body}}</section>
+{{end}}{{if .Pricing}}<section>
+<h2>Pricing</h2>
+<table>
+<tr><th>Item</th><th class="amount">Amount</th></tr>
+{{range .Pricing}}<tr{{if .Total}} class="total"{{end}}><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{end}}</table>
+</section>
+{{end}}{{if .Milestones}}<section>
+<h2>Payment schedule</h2>
+<table>
+<tr><th>Milestone</th><th>Due</th><th class="amount">Amount</th></tr>
+{{range .Milestones}}<tr><td>{{.Title}}</td><td>{{.Due}}</td><td class="amount">{{.Amount}}</td></tr>
+{{end}}</table>
+</section>
+{{end}}{{if .Footer}}<footer>{{.Footer}}</footer>
+{{end}}</body>
+</html>
+`))
+
+type htmlSection struct {
+	Heading string
+	Body    template.HTML
+}
+
+type htmlMilestone struct {
+	Title  string
+	Due    string
+	Amount string
+}
+
+// renderHTML writes the proposal as a standalone HTML page. Section bodies are
+// rendered as sanitized HTML and all other text is escaped; the logo is
+// inlined as a data URI.
+func renderHTML(w io.Writer, p *model.Proposal, b Branding) error {
+	accent := b.accent()
+	if !ValidAccentColor(accent) {
+		accent = DefaultAccentColor
+	}
+	data := struct {
+		Title      string
+		Accent     template.CSS
+		Logo       template.URL
+		Deadline   string
+		Sections   []htmlSection
+		Pricing    []pricingRow
+		Milestones []htmlMilestone
+		Footer     string
+	}{
+		Title:  p.Title,
+		Accent: template.CSS(accent),
+		Footer: b.Footer,
+	}
+	if len(b.Logo) > 0 {
+		data.Logo = template.URL("data:" + b.LogoType + ";base64," + base64.StdEncoding.EncodeToString(b.Logo))
+	}
+	if !p.Deadline.IsZero() {
+		data.Deadline = formatDate(p.Deadline)
+	}
+	for _, sec := range sections(p) {
+		data.Sections = append(data.Sections, htmlSection{
+			Heading: sec.Heading,
+			Body:    template.HTML(richtext.ToHTML(sec.Format, sec.Body)),
+		})
+	}
+	if p.Pricing != nil {
+		data.Pricing = pricingRows(p.Pricing)
+		for _, m := range p.Milestones {
+			data.Milestones = append(data.Milestones, htmlMilestone{
+				Title:  m.Title,
+				Due:    formatDate(m.DueDate),
+				Amount: formatMoney(m.AmountCents, p.Pricing.Currency),
+			})
+		}
+	}
+	return htmlPage.Execute(w, data)
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+)
+
+// renderMarkdown writes the proposal as Markdown. Markdown section bodies are
+// copied as is, while the title, headings and other text are escaped so they
+// read as written; the logo is inlined as a data URI so the file stands
+// alone.
+func renderMarkdown(w io.Writer, p *model.Proposal, b Branding) error {
+	bw := bufio.NewWriter(w)
+
+	if len(b.Logo) > 0 {
+		fmt.Fprintf(bw, "![Logo](data:%s;base64,%s)\n\n", b.LogoType, base64.StdEncoding.EncodeToString(b.Logo))
+	}
+	fmt.Fprintf(bw, "# %s\n\n", markdownInline(p.Title))
+	if !p.Deadline.IsZero() {
+		fmt.Fprintf(bw, "**Deadline:** %s\n\n", formatDate(p.Deadline))
+	}
+
+	for _, sec := range sections(p) {
+		fmt.Fprintf(bw, "## %s\n\n", markdownInline(sec.Heading))
+		if body := markdownBody(sec.Format, sec.Body); body != "" {
+			fmt.Fprintf(bw, "%s\n\n", body)
+		}
+	}
+
+	if p.Pricing != nil {
+		bw.WriteString("## Pricing\n\n| Item | Amount |\n| --- | ---: |\n")
+		for _, row := range pricingRows(p.Pricing) {
+			label, amount := markdownInline(row.Label), row.Amount
+			if row.Total {
+				label, amount = "**"+label+"**", "**"+amount+"**"
+			}
+			fmt.Fprintf(bw, "| %s | %s |\n", label, amount)
+		}
+		bw.WriteString("\n")
+	}
+
+	if len(p.Milestones) > 0 && p.Pricing != nil {
+		bw.WriteString("## Payment schedule\n\n| Milestone | Due | Amount |\n| --- | --- | ---: |\n")
+		for _, m := range p.Milestones {
+			fmt.Fprintf(bw, "| %s | %s | %s |\n", markdownInline(m.Title), formatDate(m.DueDate), formatMoney(m.AmountCents, p.Pricing.Currency))
+		}
+		bw.WriteString("\n")
+	}
+
+	if b.Footer != "" {
+		fmt.Fprintf(bw, "---\n\n%s\n", markdownBody(richtext.FormatPlain, b.Footer))
+	}
+	return bw.Flush()
+}
+
+// markdownBody converts a body to Markdown. Plain text is escaped with its
+// paragraphs and line breaks kept, and HTML is reduced to its text first.
+func markdownBody(format, body string) string {
+	switch format {
+	case richtext.FormatHTML:
+		body = richtext.PlainText(format, body)
+	case richtext.FormatPlain:
+	default:
+		return strings.TrimSpace(body)
+	}
+
+	var blocks []string
+	for _, lines := range paragraphs(body) {
+		for i, line := range lines {
+			lines[i] = markdownText(strings.TrimSpace(line))
+		}
+		// A trailing backslash is a hard line break.
+		blocks = append(blocks, strings.Join(lines, "\\\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// markdownInline escapes text for a single line, such as a heading or a
+// table cell.
+func markdownInline(s string) string {
+	return markdownText(strings.Join(strings.Fields(s), " "))
+}
+
+var (
+	markdownSpecial = strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+		"|", `\|`, "~", `\~`, "!", `\!`, "#", `\#`, "&", `\&`,
+	)
+	// Characters that only start a block at the beginning of a line: list
+	// bullets, setext underlines and ordered list numbers.
+	markdownBullet  = regexp.MustCompile(`^[-+=]`)
+	markdownOrdered = regexp.MustCompile(`^([0-9]+)([.)])`)
+)
+
+// markdownText escapes one line of text so Markdown shows it as written.
+func markdownText(line string) string {
+	line = markdownSpecial.Replace(line)
+	line = markdownBullet.ReplaceAllString(line, `\$0`)
+	return markdownOrdered.ReplaceAllString(line, `$1\$2`)
+}
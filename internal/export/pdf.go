@@ -0,0 +1,129 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// renderPDF writes the proposal as an A4 PDF. It uses the core PDF fonts, so
+// text outside Windows-1252 cannot be shown; section bodies are set as plain
+// text with HTML markup removed.
+func renderPDF(w io.Writer, p *model.Proposal, b Branding) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(p.Title, true)
+	pdf.SetCreator("freelanceX", true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 25)
+	pdf.AliasNbPages("")
+
+	r, g, bl := hexColor(b.accent())
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-18)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(110, 110, 110)
+		if b.Footer != "" {
+			pdf.CellFormat(0, 4, tr(b.Footer), "", 1, "C", false, 0, "")
+		}
+		pdf.CellFormat(0, 4, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	pageWidth, _ := pdf.GetPageSize()
+	left, top, right, _ := pdf.GetMargins()
+	width := pageWidth - left - right
+
+	if len(b.Logo) > 0 {
+		imageType := "PNG"
+		if b.LogoType == "image/jpeg" {
+			imageType = "JPG"
+		}
+		options := gofpdf.ImageOptions{ImageType: imageType}
+		info := pdf.RegisterImageOptionsReader("logo", options, bytes.NewReader(b.Logo))
+		if !pdf.Ok() {
+			// Some valid images (e.g. interlaced PNGs) cannot be embedded;
+			// leave the logo out rather than fail the export.
+			pdf.ClearError()
+		} else if info != nil {
+			const logoHeight = 18
+			logoWidth := info.Width() * logoHeight / info.Height()
+			pdf.ImageOptions("logo", pageWidth-right-logoWidth, top, logoWidth, logoHeight, false, options, 0, "")
+			pdf.SetY(top + logoHeight + 4)
+		}
+	}
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.SetTextColor(r, g, bl)
+	pdf.MultiCell(width, 9, tr(p.Title), "", "L", false)
+	pdf.SetDrawColor(r, g, bl)
+	pdf.SetLineWidth(0.8)
+	pdf.Line(left, pdf.GetY()+1, pageWidth-right, pdf.GetY()+1)
+	pdf.Ln(5)
+
+	if !p.Deadline.IsZero() {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetTextColor(80, 80, 80)
+		pdf.CellFormat(20, 6, "Deadline:", "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, formatDate(p.Deadline), "", 1, "L", false, 0, "")
+		pdf.Ln(3)
+	}
+
+	heading := func(text string) {
+		pdf.Ln(3)
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.SetTextColor(r, g, bl)
+		pdf.MultiCell(width, 7, tr(text), "", "L", false)
+		pdf.Ln(1)
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.SetTextColor(34, 34, 34)
+	}
+
+	for _, sec := range sections(p) {
+		heading(sec.Heading)
+		for _, para := range paragraphs(richtext.PlainText(sec.Format, sec.Body)) {
+			pdf.MultiCell(width, 5.5, tr(strings.Join(para, "\n")), "", "L", false)
+			pdf.Ln(2)
+		}
+	}
+
+	if p.Pricing != nil {
+		heading("Pricing")
+		for _, row := range pricingRows(p.Pricing) {
+			border := "B"
+			if row.Total {
+				pdf.SetFont("Helvetica", "B", 11)
+				border = "T"
+			}
+			pdf.CellFormat(width*0.7, 7, tr(row.Label), border, 0, "L", false, 0, "")
+			pdf.CellFormat(width*0.3, 7, tr(row.Amount), border, 1, "R", false, 0, "")
+		}
+		pdf.SetFont("Helvetica", "", 11)
+
+		if len(p.Milestones) > 0 {
+			heading("Payment schedule")
+			for _, m := range p.Milestones {
+				pdf.CellFormat(width*0.5, 7, tr(m.Title), "B", 0, "L", false, 0, "")
+				pdf.CellFormat(width*0.25, 7, formatDate(m.DueDate), "B", 0, "L", false, 0, "")
+				pdf.CellFormat(width*0.25, 7, tr(formatMoney(m.AmountCents, p.Pricing.Currency)), "B", 1, "R", false, 0, "")
+			}
+		}
+	}
+
+	return pdf.Output(w)
+}
+
+// hexColor splits a #RRGGBB color, falling back to the default accent.
+func hexColor(c string) (int, int, int) {
+	if !ValidAccentColor(c) {
+		c = DefaultAccentColor
+	}
+	v, _ := strconv.ParseUint(c[1:], 16, 32)
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)
+}
@@ -3,18 +3,20 @@ package handler
 import (
 	"context"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/auth"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/diff"
 	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
 	"github.com/Prototype-1/freelanceX_proposal_service/internal/service"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	pb "github.com/Prototype-1/freelanceX_proposal_service/proto"
-	"github.com/Prototype-1/freelanceX_proposal_service/kafka"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
     "google.golang.org/grpc/status"
 	"time"
-	"log"
-	"fmt"
-	"google.golang.org/grpc/metadata"
+	"html"
+	"io"
 	"strings"
 )
 
@@ -27,25 +29,47 @@ func NewProposalHandler(service *service.ProposalService) *ProposalHandler {
 	return &ProposalHandler{service: service}
 }
 
+// extractRole returns the role from the token verified by the auth
+// interceptor; request metadata is never trusted for identity.
 func extractRole(ctx context.Context) string {
-	md, ok := metadata.FromIncomingContext(ctx)
+	claims, ok := auth.FromContext(ctx)
 	if !ok {
 		return ""
 	}
-	roles := md.Get("role")
-	if len(roles) == 0 {
+	return claims.Role
+}
+
+func extractUserID(ctx context.Context) string {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
 		return ""
 	}
-	return strings.ToLower(roles[0])
+	return claims.UserID
+}
+
+// extractActor identifies the caller. Every RPC that touches a user's records
+// needs the user id, so a missing one is rejected up front.
+func extractActor(ctx context.Context) (model.Actor, error) {
+	actor := model.Actor{
+		UserID: extractUserID(ctx),
+		Role:   extractRole(ctx),
+	}
+	if actor.UserID == "" {
+		return actor, status.Error(codes.Unauthenticated, "authenticated user id is required")
+	}
+	return actor, nil
 }
 
 func (h *ProposalHandler) CreateProposal(ctx context.Context, req *pb.CreateProposalRequest) (*pb.CreateProposalResponse, error) {
     if extractRole(ctx) != "freelancer" {
         return nil, status.Error(codes.PermissionDenied, "only freelancers can create proposals")
     }
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
     
     var deadline time.Time
-    var err error
     if req.GetDeadlineStr() != "" {
         deadline, err = time.Parse(time.RFC3339, req.GetDeadlineStr())
         if err != nil {
@@ -53,8 +77,6 @@ func (h *ProposalHandler) CreateProposal(ctx context.Context, req *pb.CreateProp
         }
     } else if req.GetDeadline() != nil {
         deadline = req.GetDeadline().AsTime()
-    } else {
-        deadline = time.Now()
     }
     
 var title string
@@ -66,67 +88,80 @@ if req.GetContent() != nil {
     content = strings.TrimSpace(req.GetContent().GetValue())
 }
     
-    if req.GetTemplateId() == "" && (title == "" || content == "") {
+    callerSections := sectionsFromProto(req.GetSections())
+    if req.GetTemplateId() == "" && (title == "" || (content == "" && len(callerSections) == 0)) {
         return nil, status.Errorf(codes.InvalidArgument, "template_id must be provided")
     }
     
     var sections []model.Section  
+    var templateRef *primitive.ObjectID
+    var templateVersion int
     if req.GetTemplateId() != "" {
         templateID, err := primitive.ObjectIDFromHex(req.GetTemplateId())
         if err != nil || templateID == primitive.NilObjectID {
             return nil, status.Errorf(codes.InvalidArgument, "invalid template ID")
         }
         
-        template, err := h.service.GetTemplateByID(ctx, templateID)
+        template, err := h.service.GetTemplateByID(ctx, templateID, actor)
         if err != nil {
-            return nil, status.Errorf(codes.NotFound, "template not found")
+            return nil, err
+        }
+        if template.DeletedAt != nil {
+            return nil, status.Errorf(codes.FailedPrecondition, "template %s has been deleted", templateID.Hex())
         }
         
-        sections = template.Sections
-        title = template.Title
-        
-        var sb strings.Builder
-        for _, sec := range template.Sections {
-            sb.WriteString(fmt.Sprintln(sec.Heading, sec.Body))
+        templateTitle, renderedSections, err := h.service.RenderTemplate(template, req.GetVariables())
+        if err != nil {
+            return nil, err
+        }
+        if title == "" {
+            title = templateTitle
         }
-        content = sb.String()
+        sections = renderedSections
+        templateRef = &template.ID
+        templateVersion = template.Version
     }
     
+    contentFormat := req.GetContentFormat()
+    if templateRef != nil || len(callerSections) > 0 {
+        if content != "" {
+            callerSections = append(callerSections, model.Section{Heading: "Notes", Body: content, Format: contentFormat})
+        }
+        sections, err = h.service.MergeSections(sections, callerSections)
+        if err != nil {
+            return nil, err
+        }
+        content = model.ContentFromSections(sections)
+        contentFormat = richtext.FormatMarkdown
+    }
+    
+    freelancerID := strings.TrimSpace(req.GetFreelancerId())
+    if freelancerID == "" {
+        freelancerID = actor.UserID
+    }
+
     proposal := model.Proposal{
         ClientID:     strings.TrimSpace(req.GetClientId()),
-        FreelancerID: strings.TrimSpace(req.GetFreelancerId()),
+        FreelancerID: freelancerID,
         Title:        title,  
         Content:      content, 
+        ContentFormat: contentFormat,
         Status:       "draft",
         Version:      1,
         Deadline:     deadline,
         Sections:     sections,
+        JobID:        strings.TrimSpace(req.GetJobId()),
+        TemplateID:   templateRef,
+        TemplateVersion: templateVersion,
+        Pricing:      pricingFromProto(req.GetPricing()),
+        Milestones:   milestonesFromProto(req.GetMilestones()),
     }
     
-    createdProposal, err := h.service.CreateProposal(ctx, proposal)
+    createdProposal, err := h.service.CreateProposal(ctx, proposal, actor)
     if err != nil {
         return nil, err
     }
     
-    go func() {
-        bgCtx := context.Background()
-        event := kafka.ProposalEvent{
-            ProposalID:   createdProposal.ID.Hex(),
-            ClientID:     createdProposal.ClientID,
-            FreelancerID: createdProposal.FreelancerID,
-            Title:        createdProposal.Title,
-            EventType:    "proposal.created",
-            Status:       "sent",
-        }
-        if err := kafka.ProduceProposalEvent("kafka:9092", "proposal-events", event); err != nil {
-            log.Printf("failed to produce proposal.created event: %v", err)
-            return
-        }
-        if _, err := h.service.UpdateProposal(bgCtx, createdProposal.ID.Hex(), model.Proposal{Status: "sent"}); err != nil {
-            log.Printf("failed to update proposal status to sent: %v", err)
-        }
-    }()
-    
     return &pb.CreateProposalResponse{
         ProposalId: createdProposal.ID.Hex(),
         Status:     "created",
@@ -138,8 +173,12 @@ func (h *ProposalHandler) GetProposalByID(ctx context.Context, req *pb.GetPropos
 	if role != "freelancer" && role != "client" {
 		return nil, status.Error(codes.PermissionDenied, "you are unauthorized to get proposal")
 	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	proposal, err := h.service.GetProposalByID(ctx, req.GetProposalId())
+	proposal, err := h.service.GetProposalByID(ctx, req.GetProposalId(), actor)
 	if err != nil {
 		return nil, err
 	}
@@ -154,13 +193,21 @@ return &pb.GetProposalResponse{
 	ClientId:      proposal.ClientID,
 	FreelancerId:  proposal.FreelancerID,
 	TemplateId:    templateID,
+	TemplateVersion: int32(proposal.TemplateVersion),
+	Pricing:       convertPricing(proposal.Pricing),
+	Milestones:    convertMilestones(proposal.Milestones),
+	Attachments:   convertAttachments(proposal.Attachments),
     Title:         wrapperspb.String(proposal.Title),
     Content:       wrapperspb.String(proposal.Content),
+	ContentFormat: bodyFormat(proposal.ContentFormat),
+	ContentHtml:   contentHTML(proposal.Sections, proposal.Content, proposal.ContentFormat),
 	Sections: convertSections(proposal.Sections),
 	Status:        proposal.Status,
 	Version:       int32(proposal.Version),
-	Deadline:      timestamppb.New(proposal.Deadline),
-	DeadlineStr:   proposal.Deadline.Format(time.RFC3339),
+	Deadline:      deadlineProto(proposal.Deadline),
+	DeadlineStr:   deadlineString(proposal.Deadline),
+	JobId:         proposal.JobID,
+	ContractId:    proposal.ContractID,
 	CreatedAt:     timestamppb.New(proposal.CreatedAt),
 	UpdatedAt:     timestamppb.New(proposal.UpdatedAt),
 }, nil
@@ -172,48 +219,61 @@ func (h *ProposalHandler) UpdateProposal(ctx context.Context, req *pb.UpdateProp
 	update := model.Proposal{
 		Title:   req.GetTitle(),
 		Content: req.GetContent(),
+		ContentFormat: req.GetContentFormat(),
+		Pricing: pricingFromProto(req.GetPricing()),
+		Milestones: milestonesFromProto(req.GetMilestones()),
+		Version: int(req.GetVersion()),
+	}
+	if len(req.GetSections()) > 0 {
+		update.Sections = sectionsFromProto(req.GetSections())
+		for i, sec := range req.GetSections() {
+			update.Sections[i].ID = sec.GetId()
+		}
 	}
 
-	if req.GetDeadline() != nil {
+	if req.GetDeadlineStr() != "" {
+		deadline, err := time.Parse(time.RFC3339, req.GetDeadlineStr())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid deadline format: %v", err)
+		}
+		update.Deadline = deadline
+	} else if req.GetDeadline() != nil {
 		update.Deadline = req.GetDeadline().AsTime()
 	}
 
+	fields, err := updateMaskFields(req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, err
+	}
+
 	if role == "client" {
-		if req.GetTitle() != "" || req.GetContent() != "" || req.GetDeadline() != nil {
+		if req.GetTitle() != "" || req.GetContent() != "" || req.GetContentFormat() != "" || !update.Deadline.IsZero() || update.Pricing != nil || update.Milestones != nil || update.Sections != nil {
 			return nil, status.Error(codes.PermissionDenied, "clients can only update status")
 		}
-newStatus := req.GetStatus()
-if newStatus != "accepted" && newStatus != "rejected" {
-    return nil, status.Error(codes.PermissionDenied, "clients can only set status to accepted or rejected")
-}
-update.Status = newStatus
-		
+		for _, field := range fields {
+			if field != model.FieldStatus {
+				return nil, status.Error(codes.PermissionDenied, "clients can only update status")
+			}
+		}
+		if req.GetStatus() == "" {
+			return nil, status.Error(codes.InvalidArgument, "status is required")
+		}
 	}
 
 	if role != "freelancer" && role != "client" {
 		return nil, status.Error(codes.PermissionDenied, "unauthorized to update proposal")
 	}
-
-	updatedProposal, err := h.service.UpdateProposal(ctx, req.GetProposalId(), update)
+	actor, err := extractActor(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if update.Status != "" {
-		event := kafka.ProposalEvent{
-			ProposalID:   updatedProposal.ID.Hex(),
-			ClientID:     updatedProposal.ClientID,
-			FreelancerID: updatedProposal.FreelancerID,
-			Title:        updatedProposal.Title,
-			EventType:    "proposal.status.updated",
-			Status:       updatedProposal.Status,
-		}
+	// Which transitions each role may make is enforced by the service.
+	update.Status = req.GetStatus()
 
-		go func() {
-			if err := kafka.ProduceProposalEvent("kafka:9092", "proposal-events", event); err != nil {
-				log.Printf("failed to produce proposal.updated event: %v", err)
-			}
-		}()
+	updatedProposal, err := h.service.UpdateProposal(ctx, req.GetProposalId(), update, fields, actor)
+	if err != nil {
+		return nil, err
 	}
 
 	return &pb.UpdateProposalResponse{
@@ -223,28 +283,80 @@ update.Status = newStatus
 	}, nil
 }
 
+// deadlineProto and deadlineString leave out the deadline of a proposal
+// created without one.
+func deadlineProto(deadline time.Time) *timestamppb.Timestamp {
+	if deadline.IsZero() {
+		return nil
+	}
+	return timestamppb.New(deadline)
+}
+
+func deadlineString(deadline time.Time) string {
+	if deadline.IsZero() {
+		return ""
+	}
+	return deadline.Format(time.RFC3339)
+}
+
+var updateMaskPaths = map[string]string{
+	"title":          model.FieldTitle,
+	"content":        model.FieldContent,
+	"content_format": model.FieldContentFormat,
+	"deadline":       model.FieldDeadline,
+	"deadline_str":   model.FieldDeadline,
+	"status":         model.FieldStatus,
+	"sections":       model.FieldSections,
+	"pricing":        model.FieldPricing,
+	"milestones":     model.FieldMilestones,
+}
+
+// updateMaskFields maps UpdateProposalRequest mask paths to proposal fields.
+func updateMaskFields(paths []string) ([]string, error) {
+	var fields []string
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		field, ok := updateMaskPaths[path]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update_mask path %q", path)
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
 func (h *ProposalHandler) SaveTemplate(ctx context.Context, req *pb.SaveTemplateRequest) (*pb.SaveTemplateResponse, error) {
 	if extractRole(ctx) != "freelancer" {
 		return nil, status.Error(codes.PermissionDenied, "only freelancers can save templates")
 	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := req.GetFreelancerId()
+	if ownerID == "" {
+		ownerID = actor.UserID
+	}
 
 	template := model.Template{
-		OwnerID: req.GetFreelancerId(),
-		Title:   req.GetTitle(),
-		Sections: []model.Section{
-			{
-				Heading: "Default Heading",
-				Body:    req.GetContent(),
-			},
-		},
+		OwnerID:     ownerID,
+		Title:       req.GetTitle(),
+		Description: strings.TrimSpace(req.GetDescription()),
+		Sections:    templateSections(req.GetSections(), req.GetContent()),
+		Variables:   templateVariables(req.GetVariables()),
 	}
 
-	_, err := h.service.SaveTemplate(ctx, template)
+	saved, err := h.service.SaveTemplate(ctx, template, actor)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.SaveTemplateResponse{
+		TemplateId: saved.ID.Hex(),
 		Status: "created",
 	}, nil
 }
@@ -253,28 +365,178 @@ func (h *ProposalHandler) GetTemplatesForFreelancer(ctx context.Context, req *pb
 	if extractRole(ctx) != "freelancer" {
 		return nil, status.Error(codes.PermissionDenied, "only freelancers can view templates")
 	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	templates, err := h.service.GetTemplatesForFreelancer(ctx, req.GetFreelancerId())
+	freelancerID := req.GetFreelancerId()
+	if freelancerID == "" {
+		freelancerID = actor.UserID
+	}
+
+	templates, total, err := h.service.GetTemplatesForFreelancer(ctx, freelancerID, req.GetSkip(), req.GetLimit(), actor)
 	if err != nil {
 		return nil, err
 	}
 
 	var pbTemplates []*pb.Template
 	for _, template := range templates {
-		var sectionsContent string
-		for _, section := range template.Sections {
-			sectionsContent += section.Heading + ": " + section.Body + "\n"
+		pbTemplates = append(pbTemplates, convertTemplate(template))
+	}
+
+	return &pb.GetTemplatesResponse{
+		Templates:  pbTemplates,
+		TotalCount: total,
+	}, nil
+}
+
+func convertTemplate(template *model.Template) *pb.Template {
+	return &pb.Template{
+		TemplateId:  template.ID.Hex(),
+		Title:       template.Title,
+		Description: template.Description,
+		Sections:    convertSections(template.Sections),
+		Variables:   convertTemplateVariables(template.Variables),
+		Version:     int32(template.Version),
+		CreatedAt:   timestamppb.New(template.CreatedAt),
+		UpdatedAt:   timestamppb.New(template.UpdatedAt),
+		Deleted:     template.DeletedAt != nil,
+	}
+}
+
+func templateVariables(variables []*pb.TemplateVariable) []model.TemplateVariable {
+	result := make([]model.TemplateVariable, 0, len(variables))
+	for _, v := range variables {
+		result = append(result, model.TemplateVariable{
+			Name:     v.GetName(),
+			Type:     strings.ToLower(strings.TrimSpace(v.GetType())),
+			Required: v.GetRequired(),
+			Default:  v.GetDefaultValue(),
+		})
+	}
+	return result
+}
+
+func convertTemplateVariables(variables []model.TemplateVariable) []*pb.TemplateVariable {
+	result := make([]*pb.TemplateVariable, 0, len(variables))
+	for _, v := range variables {
+		result = append(result, &pb.TemplateVariable{
+			Name:         v.Name,
+			Type:         v.Type,
+			Required:     v.Required,
+			DefaultValue: v.Default,
+		})
+	}
+	return result
+}
+
+// templateSections reads the sections of a template request, falling back to
+// the deprecated content field as a single section for older clients.
+func templateSections(sections []*pb.Section, content string) []model.Section {
+	if len(sections) == 0 {
+		if content == "" {
+			return []model.Section{}
+		}
+		return []model.Section{
+			{
+				Heading: "Default Heading",
+				Body:    content,
+			},
 		}
+	}
+
+	return sectionsFromProto(sections)
+}
 
-		pbTemplates = append(pbTemplates, &pb.Template{
-			TemplateId: template.ID.Hex(),
-			Title:      template.Title,
-			Content:    sectionsContent, 
+func sectionsFromProto(sections []*pb.Section) []model.Section {
+	result := make([]model.Section, 0, len(sections))
+	for _, sec := range sections {
+		result = append(result, model.Section{
+			Heading: strings.TrimSpace(sec.GetHeading()),
+			Body:    sec.GetBody(),
+			Format:  sec.GetFormat(),
+			Order:   int(sec.GetOrder()),
 		})
 	}
+	return result
+}
 
-	return &pb.GetTemplatesResponse{
-		Templates: pbTemplates,
+func (h *ProposalHandler) GetTemplate(ctx context.Context, req *pb.GetTemplateRequest) (*pb.GetTemplateResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can view templates")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, err := primitive.ObjectIDFromHex(req.GetTemplateId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid template ID")
+	}
+
+	template, err := h.service.GetTemplateByID(ctx, templateID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetTemplateResponse{
+		Template: convertTemplate(template),
+	}, nil
+}
+
+func (h *ProposalHandler) UpdateTemplate(ctx context.Context, req *pb.UpdateTemplateRequest) (*pb.UpdateTemplateResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can update templates")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, err := primitive.ObjectIDFromHex(req.GetTemplateId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid template ID")
+	}
+
+	update := model.Template{
+		Title:       strings.TrimSpace(req.GetTitle()),
+		Description: strings.TrimSpace(req.GetDescription()),
+		Sections:    templateSections(req.GetSections(), req.GetContent()),
+		Variables:   templateVariables(req.GetVariables()),
+	}
+
+	updated, err := h.service.UpdateTemplate(ctx, templateID, update, int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateTemplateResponse{
+		Template: convertTemplate(updated),
+	}, nil
+}
+
+func (h *ProposalHandler) DeleteTemplate(ctx context.Context, req *pb.DeleteTemplateRequest) (*pb.DeleteTemplateResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can delete templates")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, err := primitive.ObjectIDFromHex(req.GetTemplateId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid template ID")
+	}
+
+	if err := h.service.DeleteTemplate(ctx, templateID, actor); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteTemplateResponse{
+		Status: "deleted",
 	}, nil
 }
 
@@ -283,43 +545,202 @@ func (h *ProposalHandler) ListProposals(ctx context.Context, req *pb.ListProposa
 		return nil, status.Error(codes.PermissionDenied, "only admins can list proposals")
 	}
 	
-    filters := make(map[string]interface{})
-    if req.GetClientId() != "" {
-        filters["client_id"] = req.GetClientId()
+    filter, err := proposalFilterFromRequest(req)
+    if err != nil {
+        return nil, err
     }
-    if req.GetFreelancerId() != "" {
-        filters["freelancer_id"] = req.GetFreelancerId()
+
+    opts := service.ListOptions{
+        PageSize:  req.GetPageSize(),
+        PageToken: req.GetPageToken(),
+        SortBy:    req.GetSortBy(),
+        Skip:      req.GetSkip(),
+    }
+    if opts.PageSize == 0 {
+        opts.PageSize = req.GetLimit()
     }
-    if req.GetStatus() != "" {
-        filters["status"] = req.GetStatus()
+    switch req.GetSortOrder() {
+    case "", "desc":
+        opts.Descending = true
+    case "asc":
+    default:
+        return nil, status.Errorf(codes.InvalidArgument, "sort_order must be asc or desc, got %q", req.GetSortOrder())
     }
 
-    proposals, err := h.service.GetProposals(ctx, filters, req.GetSkip(), req.GetLimit())
+    list, err := h.service.GetProposals(ctx, filter, opts)
     if err != nil {
         return nil, err
     }
 
     var protoProposals []*pb.Proposal
-    for _, p := range proposals {
-        protoProposals = append(protoProposals, &pb.Proposal{
-            ProposalId:    p.ID.Hex(),
-            ClientId:      p.ClientID,
-            FreelancerId:  p.FreelancerID,
-            TemplateId:    p.TemplateID.Hex(),
-            Title:         p.Title,
-            Content:       p.Content,
-            Status:        p.Status,
-            Version:       int32(p.Version),
-            CreatedAt:     timestamppb.New(p.CreatedAt),
-            UpdatedAt:     timestamppb.New(p.UpdatedAt),
-        })
+    for _, p := range list.Proposals {
+        protoProposals = append(protoProposals, convertProposal(p))
     }
 
     return &pb.ListProposalsResponse{
-        Proposals: protoProposals,
+        Proposals:     protoProposals,
+        NextPageToken: list.NextPageToken,
+        TotalCount:    list.TotalCount,
     }, nil
 }
 
+// proposalFilterFromRequest reads the typed filters of a ListProposals
+// request; unset fields do not filter.
+func proposalFilterFromRequest(req *pb.ListProposalsRequest) (model.ProposalFilter, error) {
+	filter := model.ProposalFilter{
+		ClientID:     req.GetClientId(),
+		FreelancerID: req.GetFreelancerId(),
+		Statuses:     req.GetStatuses(),
+		Currency:     req.GetCurrency(),
+
+		IncludeArchived: req.GetIncludeArchived(),
+	}
+	if req.GetStatus() != "" {
+		filter.Statuses = append(filter.Statuses, req.GetStatus())
+	}
+	if req.GetTemplateId() != "" {
+		templateID, err := primitive.ObjectIDFromHex(req.GetTemplateId())
+		if err != nil {
+			return filter, status.Error(codes.InvalidArgument, "invalid template ID")
+		}
+		filter.TemplateID = &templateID
+	}
+
+	times := []struct {
+		ts  *timestamppb.Timestamp
+		dst *time.Time
+	}{
+		{req.GetCreatedAfter(), &filter.CreatedAfter},
+		{req.GetCreatedBefore(), &filter.CreatedBefore},
+		{req.GetUpdatedAfter(), &filter.UpdatedAfter},
+		{req.GetUpdatedBefore(), &filter.UpdatedBefore},
+		{req.GetDeadlineAfter(), &filter.DeadlineAfter},
+		{req.GetDeadlineBefore(), &filter.DeadlineBefore},
+	}
+	for _, t := range times {
+		if t.ts == nil {
+			continue
+		}
+		if err := t.ts.CheckValid(); err != nil {
+			return filter, status.Errorf(codes.InvalidArgument, "invalid time filter: %v", err)
+		}
+		*t.dst = t.ts.AsTime()
+	}
+
+	if req.GetMinTotalCents() != nil {
+		minTotal := req.GetMinTotalCents().GetValue()
+		filter.MinTotalCents = &minTotal
+	}
+	if req.GetMaxTotalCents() != nil {
+		maxTotal := req.GetMaxTotalCents().GetValue()
+		filter.MaxTotalCents = &maxTotal
+	}
+	return filter, nil
+}
+
+func convertProposal(p *model.Proposal) *pb.Proposal {
+	var templateID string
+	if p.TemplateID != nil {
+		templateID = p.TemplateID.Hex()
+	}
+
+	return &pb.Proposal{
+		ProposalId:      p.ID.Hex(),
+		ClientId:        p.ClientID,
+		FreelancerId:    p.FreelancerID,
+		TemplateId:      templateID,
+		TemplateVersion: int32(p.TemplateVersion),
+		Title:           p.Title,
+		Content:         p.Content,
+		ContentFormat:   bodyFormat(p.ContentFormat),
+		ContentHtml:     contentHTML(p.Sections, p.Content, p.ContentFormat),
+		Status:          p.Status,
+		Version:         int32(p.Version),
+		CreatedAt:       timestamppb.New(p.CreatedAt),
+		UpdatedAt:       timestamppb.New(p.UpdatedAt),
+		JobId:           p.JobID,
+		ContractId:      p.ContractID,
+		Archived:        p.ArchivedAt != nil,
+		Pricing:         convertPricing(p.Pricing),
+		Milestones:      convertMilestones(p.Milestones),
+		Attachments:     convertAttachments(p.Attachments),
+	}
+}
+
+func (h *ProposalHandler) ListProposalsByTemplate(ctx context.Context, req *pb.ListProposalsByTemplateRequest) (*pb.ListProposalsByTemplateResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can list proposals by template")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, err := primitive.ObjectIDFromHex(req.GetTemplateId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid template ID")
+	}
+
+	proposals, total, err := h.service.ListProposalsByTemplate(ctx, templateID, req.GetSkip(), req.GetLimit(), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var protoProposals []*pb.Proposal
+	for _, p := range proposals {
+		protoProposals = append(protoProposals, convertProposal(p))
+	}
+
+	return &pb.ListProposalsByTemplateResponse{
+		Proposals:  protoProposals,
+		TotalCount: total,
+	}, nil
+}
+
+func (h *ProposalHandler) GetTemplateStats(ctx context.Context, req *pb.GetTemplateStatsRequest) (*pb.GetTemplateStatsResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can view template statistics")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	freelancerID := req.GetFreelancerId()
+	if freelancerID == "" {
+		freelancerID = actor.UserID
+	}
+
+	var templateID *primitive.ObjectID
+	if req.GetTemplateId() != "" {
+		id, err := primitive.ObjectIDFromHex(req.GetTemplateId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid template ID")
+		}
+		templateID = &id
+	}
+
+	stats, err := h.service.GetTemplateStats(ctx, freelancerID, templateID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var pbStats []*pb.TemplateStats
+	for _, st := range stats {
+		pbStats = append(pbStats, &pb.TemplateStats{
+			TemplateId:     st.TemplateID.Hex(),
+			Used:           st.Used,
+			ByStatus:       st.ByStatus,
+			Won:            st.Won(),
+			AcceptanceRate: st.AcceptanceRate(),
+		})
+	}
+
+	return &pb.GetTemplateStatsResponse{
+		Stats: pbStats,
+	}, nil
+}
+
 func convertSections(sections []model.Section) []*pb.Section {
     pbSections := make([]*pb.Section, 0)
 
@@ -329,12 +750,669 @@ func convertSections(sections []model.Section) []*pb.Section {
     
     for _, sec := range sections {
         pbSections = append(pbSections, &pb.Section{
-            Heading: sec.Heading,
-            Body:    sec.Body,
+            Heading:  sec.Heading,
+            Body:     sec.Body,
+            Format:   bodyFormat(sec.Format),
+            BodyHtml: richtext.ToHTML(sec.Format, sec.Body),
+            Order:    int32(sec.Order),
+            Id:       sec.ID,
         })
     }
     return pbSections
 }
 
+// bodyFormat is the format of a stored body; bodies stored before formats
+// existed have none and are Markdown.
+func bodyFormat(format string) string {
+	if format == "" {
+		return richtext.DefaultFormat
+	}
+	return format
+}
+
+// contentHTML renders a proposal body as sanitized HTML: each section under
+// its heading, or the free-text content when there are no sections.
+func contentHTML(sections []model.Section, content, format string) string {
+	if len(sections) == 0 {
+		return richtext.ToHTML(format, content)
+	}
+	var sb strings.Builder
+	for _, sec := range sections {
+		sb.WriteString("<h2>")
+		sb.WriteString(html.EscapeString(sec.Heading))
+		sb.WriteString("</h2>\n")
+		sb.WriteString(richtext.ToHTML(sec.Format, sec.Body))
+	}
+	return sb.String()
+}
+
 
 
+
+func (h *ProposalHandler) GetProposalRevisions(ctx context.Context, req *pb.GetProposalRevisionsRequest) (*pb.GetProposalRevisionsResponse, error) {
+	role := extractRole(ctx)
+	if role != "freelancer" && role != "client" {
+		return nil, status.Error(codes.PermissionDenied, "you are unauthorized to view proposal revisions")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, revisions, err := h.service.GetProposalRevisions(ctx, req.GetProposalId(), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	pbRevisions := make([]*pb.ProposalRevision, 0, len(revisions))
+	for _, rev := range revisions {
+		pbRevisions = append(pbRevisions, convertRevision(rev))
+	}
+
+	return &pb.GetProposalRevisionsResponse{
+		CurrentVersion: int32(proposal.Version),
+		Revisions:      pbRevisions,
+	}, nil
+}
+
+func (h *ProposalHandler) GetProposalRevision(ctx context.Context, req *pb.GetProposalRevisionRequest) (*pb.GetProposalRevisionResponse, error) {
+	role := extractRole(ctx)
+	if role != "freelancer" && role != "client" {
+		return nil, status.Error(codes.PermissionDenied, "you are unauthorized to view proposal revisions")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := h.service.GetProposalVersion(ctx, req.GetProposalId(), int(req.GetVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetProposalRevisionResponse{
+		Revision: convertRevision(revision),
+	}, nil
+}
+
+func (h *ProposalHandler) RestoreRevision(ctx context.Context, req *pb.RestoreRevisionRequest) (*pb.RestoreRevisionResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can restore proposal revisions")
+	}
+	if req.GetExpectedVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "expected_version is required to restore a revision")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := h.service.RestoreRevision(ctx, req.GetProposalId(), int(req.GetVersion()), int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RestoreRevisionResponse{
+		ProposalId:          restored.ID.Hex(),
+		Status:              "restored",
+		NewVersion:          int32(restored.Version),
+		RestoredFromVersion: req.GetVersion(),
+	}, nil
+}
+
+func convertRevision(rev *model.ProposalRevision) *pb.ProposalRevision {
+	return &pb.ProposalRevision{
+		ProposalId:    rev.ProposalID.Hex(),
+		Version:       int32(rev.Version),
+		Title:         rev.Title,
+		Content:       rev.Content,
+		ContentFormat: bodyFormat(rev.ContentFormat),
+		ContentHtml:   contentHTML(rev.Sections, rev.Content, rev.ContentFormat),
+		Sections:      convertSections(rev.Sections),
+		Deadline:      deadlineProto(rev.Deadline),
+		Status:        rev.Status,
+		Pricing:       convertPricing(rev.Pricing),
+		Milestones:    convertMilestones(rev.Milestones),
+		Attachments:   convertAttachments(rev.Attachments),
+		CreatedAt:     timestamppb.New(rev.CreatedAt),
+	}
+}
+
+func (h *ProposalHandler) DiffProposalVersions(ctx context.Context, req *pb.DiffProposalVersionsRequest) (*pb.DiffProposalVersionsResponse, error) {
+	role := extractRole(ctx)
+	if role != "freelancer" && role != "client" {
+		return nil, status.Error(codes.PermissionDenied, "you are unauthorized to compare proposal versions")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := h.service.DiffProposalVersions(ctx, req.GetProposalId(), int(req.GetFromVersion()), int(req.GetToVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.DiffProposalVersionsResponse{
+		ProposalId:     req.GetProposalId(),
+		FromVersion:    int32(d.FromVersion),
+		ToVersion:      int32(d.ToVersion),
+		ContentChanges: convertLineChanges(d.Content),
+	}
+	for _, f := range d.Fields {
+		resp.FieldChanges = append(resp.FieldChanges, &pb.FieldChange{
+			Field: f.Field,
+			From:  f.From,
+			To:    f.To,
+		})
+	}
+	for _, sec := range d.Sections {
+		resp.SectionChanges = append(resp.SectionChanges, &pb.SectionChange{
+			SectionId:   sec.SectionID,
+			Heading:     sec.Heading,
+			FromHeading: sec.FromHeading,
+			Change:      sec.Change,
+			Lines:       convertLineChanges(sec.Lines),
+		})
+	}
+	return resp, nil
+}
+
+func convertLineChanges(lines []diff.Line) []*pb.LineChange {
+	pbLines := make([]*pb.LineChange, 0, len(lines))
+	for _, l := range lines {
+		pbLines = append(pbLines, &pb.LineChange{
+			Op:       string(l.Op),
+			Text:     l.Text,
+			FromLine: int32(l.FromLine),
+			ToLine:   int32(l.ToLine),
+		})
+	}
+	return pbLines
+}
+
+func sectionsResponse(p *model.Proposal, sectionID string) *pb.ProposalSectionsResponse {
+	return &pb.ProposalSectionsResponse{
+		ProposalId: p.ID.Hex(),
+		SectionId:  sectionID,
+		NewVersion: int32(p.Version),
+		Sections:   convertSections(p.Sections),
+	}
+}
+
+func (h *ProposalHandler) AddProposalSection(ctx context.Context, req *pb.AddProposalSectionRequest) (*pb.ProposalSectionsResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can edit proposal sections")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	section := model.Section{
+		Heading: req.GetHeading(),
+		Body:    req.GetBody(),
+		Format:  req.GetFormat(),
+	}
+	proposal, sectionID, err := h.service.AddSection(ctx, req.GetProposalId(), section, int(req.GetPosition()), int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+	return sectionsResponse(proposal, sectionID), nil
+}
+
+func (h *ProposalHandler) UpdateProposalSection(ctx context.Context, req *pb.UpdateProposalSectionRequest) (*pb.ProposalSectionsResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can edit proposal sections")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var edit service.SectionEdit
+	if req.GetHeading() != nil {
+		heading := req.GetHeading().GetValue()
+		edit.Heading = &heading
+	}
+	if req.GetBody() != nil {
+		body := req.GetBody().GetValue()
+		edit.Body = &body
+	}
+	if req.GetFormat() != nil {
+		format := req.GetFormat().GetValue()
+		edit.Format = &format
+	}
+	if edit.Heading == nil && edit.Body == nil && edit.Format == nil {
+		return nil, status.Error(codes.InvalidArgument, "heading, body or format must be provided")
+	}
+
+	proposal, err := h.service.UpdateSection(ctx, req.GetProposalId(), req.GetSectionId(), edit, int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+	return sectionsResponse(proposal, req.GetSectionId()), nil
+}
+
+func (h *ProposalHandler) ReorderProposalSections(ctx context.Context, req *pb.ReorderProposalSectionsRequest) (*pb.ProposalSectionsResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can edit proposal sections")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := h.service.ReorderSections(ctx, req.GetProposalId(), req.GetSectionIds(), int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+	return sectionsResponse(proposal, ""), nil
+}
+
+func (h *ProposalHandler) RemoveProposalSection(ctx context.Context, req *pb.RemoveProposalSectionRequest) (*pb.ProposalSectionsResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can edit proposal sections")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := h.service.RemoveSection(ctx, req.GetProposalId(), req.GetSectionId(), int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+	return sectionsResponse(proposal, req.GetSectionId()), nil
+}
+
+func pricingFromProto(p *pb.Pricing) *model.Pricing {
+	if p == nil {
+		return nil
+	}
+	pricing := &model.Pricing{
+		Currency:        p.GetCurrency(),
+		Type:            strings.ToLower(strings.TrimSpace(p.GetType())),
+		FixedPriceCents: p.GetFixedPriceCents(),
+		HourlyRateCents: p.GetHourlyRateCents(),
+		EstimatedHours:  p.GetEstimatedHours(),
+		Discounts:       adjustmentsFromProto(p.GetDiscounts()),
+		Taxes:           adjustmentsFromProto(p.GetTaxes()),
+	}
+	for _, item := range p.GetLineItems() {
+		pricing.LineItems = append(pricing.LineItems, model.LineItem{
+			Description:    item.GetDescription(),
+			Quantity:       item.GetQuantity(),
+			UnitPriceCents: item.GetUnitPriceCents(),
+		})
+	}
+	return pricing
+}
+
+func adjustmentsFromProto(adjustments []*pb.PriceAdjustment) []model.Adjustment {
+	var result []model.Adjustment
+	for _, adj := range adjustments {
+		result = append(result, model.Adjustment{
+			Name:        adj.GetName(),
+			PercentBps:  adj.GetPercentBps(),
+			AmountCents: adj.GetAmountCents(),
+		})
+	}
+	return result
+}
+
+func convertPricing(p *model.Pricing) *pb.Pricing {
+	if p == nil {
+		return nil
+	}
+	pricing := &pb.Pricing{
+		Currency:        p.Currency,
+		Type:            p.Type,
+		FixedPriceCents: p.FixedPriceCents,
+		HourlyRateCents: p.HourlyRateCents,
+		EstimatedHours:  p.EstimatedHours,
+		Discounts:       convertAdjustments(p.Discounts),
+		Taxes:           convertAdjustments(p.Taxes),
+		SubtotalCents:   p.SubtotalCents,
+		DiscountCents:   p.DiscountCents,
+		TaxCents:        p.TaxCents,
+		TotalCents:      p.TotalCents,
+	}
+	for _, item := range p.LineItems {
+		pricing.LineItems = append(pricing.LineItems, &pb.LineItem{
+			Description:    item.Description,
+			Quantity:       item.Quantity,
+			UnitPriceCents: item.UnitPriceCents,
+			AmountCents:    item.AmountCents,
+		})
+	}
+	return pricing
+}
+
+func convertAdjustments(adjustments []model.Adjustment) []*pb.PriceAdjustment {
+	var result []*pb.PriceAdjustment
+	for _, adj := range adjustments {
+		result = append(result, &pb.PriceAdjustment{
+			Name:         adj.Name,
+			PercentBps:   adj.PercentBps,
+			AmountCents:  adj.AmountCents,
+			AppliedCents: adj.AppliedCents,
+		})
+	}
+	return result
+}
+
+func milestonesFromProto(milestones []*pb.Milestone) []model.Milestone {
+	var result []model.Milestone
+	for _, m := range milestones {
+		milestone := model.Milestone{
+			ID:           m.GetId(),
+			Title:        m.GetTitle(),
+			Description:  m.GetDescription(),
+			AmountCents:  m.GetAmountCents(),
+			Deliverables: m.GetDeliverables(),
+		}
+		if m.GetDueDate() != nil {
+			milestone.DueDate = m.GetDueDate().AsTime()
+		}
+		result = append(result, milestone)
+	}
+	return result
+}
+
+func convertMilestones(milestones []model.Milestone) []*pb.Milestone {
+	var result []*pb.Milestone
+	for _, m := range milestones {
+		result = append(result, &pb.Milestone{
+			Id:           m.ID,
+			Title:        m.Title,
+			Description:  m.Description,
+			DueDate:      timestamppb.New(m.DueDate),
+			AmountCents:  m.AmountCents,
+			Deliverables: m.Deliverables,
+		})
+	}
+	return result
+}
+
+func (h *ProposalHandler) SetMilestones(ctx context.Context, req *pb.SetMilestonesRequest) (*pb.SetMilestonesResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can set milestones")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := h.service.SetMilestones(ctx, req.GetProposalId(), milestonesFromProto(req.GetMilestones()), int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetMilestonesResponse{
+		ProposalId: proposal.ID.Hex(),
+		NewVersion: int32(proposal.Version),
+		Milestones: convertMilestones(proposal.Milestones),
+	}, nil
+}
+
+func convertAttachments(attachments []model.Attachment) []*pb.Attachment {
+	var result []*pb.Attachment
+	for i := range attachments {
+		result = append(result, convertAttachment(&attachments[i]))
+	}
+	return result
+}
+
+func convertAttachment(a *model.Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:             a.ID,
+		Name:           a.Name,
+		SizeBytes:      a.Size,
+		ContentType:    a.ContentType,
+		ChecksumSha256: a.Checksum,
+		UploadedBy:     a.UploadedBy,
+		UploadedAt:     timestamppb.New(a.UploadedAt),
+	}
+}
+
+// uploadReader reads the chunks of an upload stream as one byte stream.
+type uploadReader struct {
+	stream grpc.ClientStreamingServer[pb.UploadAttachmentRequest, pb.UploadAttachmentResponse]
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetInfo() != nil {
+			return 0, status.Error(codes.InvalidArgument, "upload info may only be sent first")
+		}
+		r.buf = req.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (h *ProposalHandler) UploadAttachment(stream grpc.ClientStreamingServer[pb.UploadAttachmentRequest, pb.UploadAttachmentResponse]) error {
+	ctx := stream.Context()
+	if extractRole(ctx) != "freelancer" {
+		return status.Error(codes.PermissionDenied, "only freelancers can upload attachments")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first upload message must carry the attachment info")
+	}
+
+	proposal, attachment, err := h.service.UploadAttachment(ctx, info.GetProposalId(), info.GetName(), info.GetContentType(), &uploadReader{stream: stream}, actor)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&pb.UploadAttachmentResponse{
+		ProposalId: proposal.ID.Hex(),
+		NewVersion: int32(proposal.Version),
+		Attachment: convertAttachment(attachment),
+	})
+}
+
+const downloadChunkSize = 64 * 1024
+
+func (h *ProposalHandler) DownloadAttachment(req *pb.DownloadAttachmentRequest, stream grpc.ServerStreamingServer[pb.DownloadAttachmentResponse]) error {
+	ctx := stream.Context()
+	role := extractRole(ctx)
+	if role != "freelancer" && role != "client" {
+		return status.Error(codes.PermissionDenied, "you are unauthorized to download attachments")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	attachment, content, err := h.service.DownloadAttachment(ctx, req.GetProposalId(), req.GetAttachmentId(), actor)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if err := stream.Send(&pb.DownloadAttachmentResponse{
+		Payload: &pb.DownloadAttachmentResponse_Info{Info: convertAttachment(attachment)},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.DownloadAttachmentResponse{
+				Payload: &pb.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			}); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return status.Errorf(codes.Internal, "failed to read attachment: %v", readErr)
+		}
+	}
+}
+
+func (h *ProposalHandler) RemoveAttachment(ctx context.Context, req *pb.RemoveAttachmentRequest) (*pb.RemoveAttachmentResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers can remove attachments")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := h.service.RemoveAttachment(ctx, req.GetProposalId(), req.GetAttachmentId(), int(req.GetExpectedVersion()), actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RemoveAttachmentResponse{
+		ProposalId:  proposal.ID.Hex(),
+		NewVersion:  int32(proposal.Version),
+		Attachments: convertAttachments(proposal.Attachments),
+	}, nil
+}
+
+func (h *ProposalHandler) ExportProposal(req *pb.ExportProposalRequest, stream grpc.ServerStreamingServer[pb.ExportProposalResponse]) error {
+	ctx := stream.Context()
+	role := extractRole(ctx)
+	if role != "freelancer" && role != "client" {
+		return status.Error(codes.PermissionDenied, "you are unauthorized to export proposals")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	exported, err := h.service.ExportProposal(ctx, req.GetProposalId(), req.GetFormat(), actor)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(&pb.ExportProposalResponse{
+		Payload: &pb.ExportProposalResponse_Info{Info: &pb.ExportInfo{
+			FileName:    exported.FileName,
+			ContentType: exported.ContentType,
+		}},
+	}); err != nil {
+		return err
+	}
+
+	chunks := &chunkWriter{send: func(chunk []byte) error {
+		return stream.Send(&pb.ExportProposalResponse{
+			Payload: &pb.ExportProposalResponse_Chunk{Chunk: chunk},
+		})
+	}}
+	if err := exported.Render(chunks); err != nil {
+		return err
+	}
+	return chunks.Flush()
+}
+
+// chunkWriter sends what is written to it in chunks of downloadChunkSize, so
+// a document is streamed while it is still being rendered.
+type chunkWriter struct {
+	send func(chunk []byte) error
+	buf  []byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if w.buf == nil {
+			w.buf = make([]byte, 0, downloadChunkSize)
+		}
+		n := min(len(p), downloadChunkSize-len(w.buf))
+		w.buf = append(w.buf, p[:n]...)
+		p, written = p[n:], written+n
+		if len(w.buf) == downloadChunkSize {
+			if err := w.Flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Flush sends what is buffered. Each chunk gets a fresh buffer since a sent
+// message must not be modified.
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	chunk := w.buf
+	w.buf = nil
+	return w.send(chunk)
+}
+
+func convertBrandingProfile(p *model.BrandingProfile) *pb.BrandingProfile {
+	profile := &pb.BrandingProfile{
+		FreelancerId:    p.FreelancerID,
+		AccentColor:     p.AccentColor,
+		Footer:          p.Footer,
+		HasLogo:         p.LogoBlobID != "",
+		LogoContentType: p.LogoContentType,
+	}
+	if !p.UpdatedAt.IsZero() {
+		profile.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+	return profile
+}
+
+func (h *ProposalHandler) GetBrandingProfile(ctx context.Context, req *pb.GetBrandingProfileRequest) (*pb.GetBrandingProfileResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers have a branding profile")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := h.service.GetBrandingProfile(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetBrandingProfileResponse{Profile: convertBrandingProfile(profile)}, nil
+}
+
+func (h *ProposalHandler) SetBrandingProfile(ctx context.Context, req *pb.SetBrandingProfileRequest) (*pb.SetBrandingProfileResponse, error) {
+	if extractRole(ctx) != "freelancer" {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers have a branding profile")
+	}
+	actor, err := extractActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := h.service.SetBrandingProfile(ctx, service.BrandingUpdate{
+		AccentColor: req.GetAccentColor(),
+		Footer:      req.GetFooter(),
+		Logo:        req.GetLogo(),
+		RemoveLogo:  req.GetRemoveLogo(),
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetBrandingProfileResponse{Profile: convertBrandingProfile(profile)}, nil
+}
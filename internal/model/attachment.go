@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Attachment describes a file attached to a proposal. The bytes live in the
+// blob store under the attachment id.
+type Attachment struct {
+	ID          string `bson:"id"`
+	Name        string `bson:"name"`
+	Size        int64  `bson:"size"`
+	ContentType string `bson:"content_type"`
+	// Checksum is the hex SHA-256 of the content.
+	Checksum   string    `bson:"checksum"`
+	UploadedBy string    `bson:"uploaded_by"`
+	UploadedAt time.Time `bson:"uploaded_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// BrandingProfile is how a freelancer's exported proposals look. The logo
+// bytes live in the blob store under LogoBlobID.
+type BrandingProfile struct {
+	FreelancerID    string    `bson:"_id"`
+	AccentColor     string    `bson:"accent_color,omitempty"` // #RRGGBB
+	Footer          string    `bson:"footer,omitempty"`
+	LogoBlobID      string    `bson:"logo_blob_id,omitempty"`
+	LogoContentType string    `bson:"logo_content_type,omitempty"`
+	UpdatedAt       time.Time `bson:"updated_at"`
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fields proposals can be listed in order of.
+const (
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+	SortDeadline  = "deadline"
+	SortStatus    = "status"
+)
+
+// ProposalFilter narrows a proposal listing to the proposals matching every
+// set field. Ranges include their After bound and exclude their Before bound.
+type ProposalFilter struct {
+	ClientID     string
+	FreelancerID string
+	Statuses     []string // any of
+	TemplateID   *primitive.ObjectID
+
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	UpdatedAfter   time.Time
+	UpdatedBefore  time.Time
+	DeadlineAfter  time.Time
+	DeadlineBefore time.Time
+
+	// The price range applies to the pricing total and needs a currency,
+	// since totals in different currencies cannot be compared.
+	Currency      string
+	MinTotalCents *int64
+	MaxTotalCents *int64
+
+	// Proposals archived after a party was deleted are left out unless
+	// IncludeArchived is set.
+	IncludeArchived bool
+}
+
+// ProposalPage selects one page of a proposal listing. Proposals are ordered
+// by SortBy and then by id, so the order is stable even among equal values.
+type ProposalPage struct {
+	SortBy     string
+	Descending bool
+	Limit      int64
+	// After continues the listing behind a proposal of the previous page.
+	After *ProposalCursor
+	// Skip is the deprecated offset paging; it is ignored with After.
+	Skip int64
+}
+
+// ProposalCursor is the position of a proposal in a listing: its sort value
+// and id.
+type ProposalCursor struct {
+	Time   time.Time // for created_at, updated_at and deadline
+	Status string    // for status
+	ID     primitive.ObjectID
+}
+
+// CursorOf returns the position of p in a listing ordered by sortBy.
+func CursorOf(p *Proposal, sortBy string) ProposalCursor {
+	cursor := ProposalCursor{ID: p.ID}
+	switch sortBy {
+	case SortCreatedAt:
+		cursor.Time = p.CreatedAt
+	case SortDeadline:
+		cursor.Time = p.Deadline
+	case SortStatus:
+		cursor.Status = p.Status
+	default:
+		cursor.Time = p.UpdatedAt
+	}
+	return cursor
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Milestone is one staged delivery of a proposal and the amount paid for it.
+// The amounts of a proposal's milestones add up to its pricing total.
+type Milestone struct {
+	ID           string    `bson:"id"`
+	Title        string    `bson:"title"`
+	Description  string    `bson:"description,omitempty"`
+	DueDate      time.Time `bson:"due_date"`
+	AmountCents  int64     `bson:"amount_cents"`
+	Deliverables []string  `bson:"deliverables,omitempty"`
+}
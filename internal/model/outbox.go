@@ -0,0 +1,33 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	// OutboxPublished marks an event that is on Kafka but whose delivery
+	// callback has not succeeded yet. It is retried without publishing again.
+	OutboxPublished = "published"
+)
+
+// OutboxEvent is a proposal event waiting to be published. It is written in
+// the same transaction as the change it describes and its ID doubles as the
+// event id consumers use to drop duplicates.
+type OutboxEvent struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	EventType     string             `bson:"event_type"`
+	ProposalID    primitive.ObjectID `bson:"proposal_id"`
+	Actor         Actor              `bson:"actor"`
+	Before        *Proposal          `bson:"before,omitempty"`
+	After         *Proposal          `bson:"after,omitempty"`
+	Status        string             `bson:"status"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	PublishedAt   *time.Time         `bson:"published_at,omitempty"`
+	DeliveredAt   *time.Time         `bson:"delivered_at,omitempty"`
+}
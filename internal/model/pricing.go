@@ -0,0 +1,84 @@
+package model
+
+import "math"
+
+const (
+	PricingFixed  = "fixed"
+	PricingHourly = "hourly"
+)
+
+// Pricing is what a proposal asks the client to pay. Amounts are in the
+// currency's minor unit (cents) and percentages in basis points, so totals
+// never pick up floating point error.
+type Pricing struct {
+	Currency        string       `bson:"currency"`
+	Type            string       `bson:"type"`
+	FixedPriceCents int64        `bson:"fixed_price_cents,omitempty"`
+	HourlyRateCents int64        `bson:"hourly_rate_cents,omitempty"`
+	EstimatedHours  float64      `bson:"estimated_hours,omitempty"`
+	LineItems       []LineItem   `bson:"line_items,omitempty"`
+	Discounts       []Adjustment `bson:"discounts,omitempty"`
+	Taxes           []Adjustment `bson:"taxes,omitempty"`
+
+	// Computed by ComputeTotals.
+	SubtotalCents int64 `bson:"subtotal_cents"`
+	DiscountCents int64 `bson:"discount_cents"`
+	TaxCents      int64 `bson:"tax_cents"`
+	TotalCents    int64 `bson:"total_cents"`
+}
+
+// LineItem is a charge on top of the fixed price or hourly estimate.
+type LineItem struct {
+	Description    string `bson:"description"`
+	Quantity       int64  `bson:"quantity"`
+	UnitPriceCents int64  `bson:"unit_price_cents"`
+	AmountCents    int64  `bson:"amount_cents"`
+}
+
+// Adjustment is a discount or tax given either as a percentage in basis
+// points or as a flat amount.
+type Adjustment struct {
+	Name         string `bson:"name"`
+	PercentBps   int64  `bson:"percent_bps,omitempty"`
+	AmountCents  int64  `bson:"amount_cents,omitempty"`
+	AppliedCents int64  `bson:"applied_cents"`
+}
+
+// BaseCents is the fixed price, or the hourly rate times the estimated hours
+// rounded to the nearest cent.
+func (p *Pricing) BaseCents() int64 {
+	if p.Type == PricingHourly {
+		return int64(math.Round(float64(p.HourlyRateCents) * p.EstimatedHours))
+	}
+	return p.FixedPriceCents
+}
+
+// ComputeTotals fills in line item amounts, applied adjustments and the
+// totals. Discounts apply to the subtotal and taxes to the discounted
+// subtotal.
+func (p *Pricing) ComputeTotals() {
+	p.SubtotalCents = p.BaseCents()
+	for i := range p.LineItems {
+		item := &p.LineItems[i]
+		item.AmountCents = item.Quantity * item.UnitPriceCents
+		p.SubtotalCents += item.AmountCents
+	}
+
+	p.DiscountCents = applyAdjustments(p.Discounts, p.SubtotalCents)
+	p.TaxCents = applyAdjustments(p.Taxes, p.SubtotalCents-p.DiscountCents)
+	p.TotalCents = p.SubtotalCents - p.DiscountCents + p.TaxCents
+}
+
+func applyAdjustments(adjustments []Adjustment, basis int64) int64 {
+	var total int64
+	for i := range adjustments {
+		adj := &adjustments[i]
+		if adj.PercentBps != 0 {
+			adj.AppliedCents = int64(math.Round(float64(basis) * float64(adj.PercentBps) / 10000))
+		} else {
+			adj.AppliedCents = adj.AmountCents
+		}
+		total += adj.AppliedCents
+	}
+	return total
+}
@@ -0,0 +1,87 @@
+package model
+
+import "testing"
+
+func TestComputeTotals(t *testing.T) {
+	tests := []struct {
+		name    string
+		pricing Pricing
+		want    [4]int64 // subtotal, discount, tax, total
+	}{
+		{
+			name:    "fixed price",
+			pricing: Pricing{Type: PricingFixed, FixedPriceCents: 100_000},
+			want:    [4]int64{100_000, 0, 0, 100_000},
+		},
+		{
+			name:    "hourly estimate rounds to the nearest cent",
+			pricing: Pricing{Type: PricingHourly, HourlyRateCents: 4_999, EstimatedHours: 2.5},
+			want:    [4]int64{12_498, 0, 0, 12_498}, // 12,497.5
+		},
+		{
+			name: "line items, discount on the subtotal, tax on the discounted subtotal",
+			pricing: Pricing{
+				Type:            PricingFixed,
+				FixedPriceCents: 50_000,
+				LineItems:       []LineItem{{Description: "Hosting", Quantity: 12, UnitPriceCents: 2_500}},
+				Discounts:       []Adjustment{{Name: "Loyalty", PercentBps: 1_000}, {Name: "Voucher", AmountCents: 2_000}},
+				Taxes:           []Adjustment{{Name: "VAT", PercentBps: 2_000}},
+			},
+			// 80,000 - (8,000 + 2,000) = 70,000, plus 20% tax.
+			want: [4]int64{80_000, 10_000, 14_000, 84_000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.pricing
+			p.ComputeTotals()
+			got := [4]int64{p.SubtotalCents, p.DiscountCents, p.TaxCents, p.TotalCents}
+			if got != tt.want {
+				t.Errorf("totals = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeTotalsFillsAmounts(t *testing.T) {
+	p := Pricing{
+		Type:            PricingFixed,
+		FixedPriceCents: 10_000,
+		LineItems:       []LineItem{{Description: "Icons", Quantity: 3, UnitPriceCents: 1_500}},
+		Discounts:       []Adjustment{{Name: "Launch", PercentBps: 500}},
+		Taxes:           []Adjustment{{Name: "Levy", AmountCents: 300}},
+	}
+	p.ComputeTotals()
+
+	if p.LineItems[0].AmountCents != 4_500 {
+		t.Errorf("line item amount = %d, want 4500", p.LineItems[0].AmountCents)
+	}
+	if p.Discounts[0].AppliedCents != 725 {
+		t.Errorf("discount applied = %d, want 725", p.Discounts[0].AppliedCents)
+	}
+	if p.Taxes[0].AppliedCents != 300 {
+		t.Errorf("flat tax applied = %d, want its amount", p.Taxes[0].AppliedCents)
+	}
+}
+
+func TestBasisPointRounding(t *testing.T) {
+	tests := []struct {
+		basis, bps, want int64
+	}{
+		{10_000, 1, 1},   // 1 cent exactly
+		{1_000, 125, 13}, // 12.5 rounds half away from zero
+		{1_000, 115, 12}, // 11.5
+		{999, 50, 5},     // 4.995
+		{1, 50, 0},       // 0.005
+		{1, 5_000, 1},    // 0.5
+		{3, 3_333, 1},    // 0.9999
+		{12_345, 10_000, 12_345},
+		{0, 2_000, 0},
+	}
+	for _, tt := range tests {
+		adjustments := []Adjustment{{Name: "x", PercentBps: tt.bps}}
+		if got := applyAdjustments(adjustments, tt.basis); got != tt.want || adjustments[0].AppliedCents != tt.want {
+			t.Errorf("%d bps of %d = %d (applied %d), want %d", tt.bps, tt.basis, got, adjustments[0].AppliedCents, tt.want)
+		}
+	}
+}
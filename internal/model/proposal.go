@@ -1,7 +1,9 @@
 package model
 
 import (
+	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
 	"time"
 )
 
@@ -10,12 +12,23 @@ type Proposal struct {
 	ClientID    string             `bson:"client_id"`
 	FreelancerID string           `bson:"freelancer_id"`
 TemplateID   *primitive.ObjectID `bson:"template_id,omitempty"`
+	// TemplateVersion is the version of the template the proposal was
+	// created from.
+	TemplateVersion int `bson:"template_version,omitempty"`
 	Title       string             `bson:"title"`
 	Content     string             `bson:"content"`
+	// ContentFormat is how Content is written: plain, markdown or html.
+	ContentFormat string `bson:"content_format,omitempty"`
 	Status      string             `bson:"status"` 
 	Version     int                `bson:"version"`
 	Deadline     time.Time          `bson:"deadline"`
 	Sections  []Section  `bson:"sections,omitempty"`
+	JobID      string             `bson:"job_id,omitempty"`
+	ContractID string             `bson:"contract_id,omitempty"`
+	ArchivedAt *time.Time         `bson:"archived_at,omitempty"`
+	Pricing    *Pricing           `bson:"pricing,omitempty"`
+	Milestones []Milestone        `bson:"milestones,omitempty"`
+	Attachments []Attachment     `bson:"attachments,omitempty"`
 	CreatedAt   time.Time          `bson:"created_at"`
 	UpdatedAt   time.Time          `bson:"updated_at"`
 }
@@ -26,11 +39,154 @@ type Template struct {
 	Title     string             `bson:"title"`
 	Description string             `bson:"description"`
 	Sections  []Section          `bson:"sections"`
+	Variables []TemplateVariable `bson:"variables,omitempty"`
+	Version   int                `bson:"version"`
+	// DeletedAt marks a soft-deleted template: hidden from listings and
+	// unusable for new proposals, but still resolvable by existing ones.
+	DeletedAt *time.Time         `bson:"deleted_at,omitempty"`
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
 }
 
+// TemplateVariable declares a {{name}} placeholder that is filled in when a
+// proposal is created from the template.
+type TemplateVariable struct {
+	Name     string `bson:"name"`
+	Type     string `bson:"type"`
+	Required bool   `bson:"required"`
+	Default  string `bson:"default,omitempty"`
+}
+
+const (
+	VariableString = "string"
+	VariableNumber = "number"
+	// VariableDate values are written as YYYY-MM-DD.
+	VariableDate = "date"
+)
+
+// Proposal fields an update can set, named as stored.
+const (
+	FieldTitle       = "title"
+	FieldContent     = "content"
+	FieldStatus      = "status"
+	FieldDeadline    = "deadline"
+	FieldSections    = "sections"
+	FieldContractID  = "contract_id"
+	FieldPricing     = "pricing"
+	FieldMilestones  = "milestones"
+	FieldAttachments = "attachments"
+
+	// FieldContentFormat changes only the format of the current content.
+	FieldContentFormat = "content_format"
+)
+
+// TemplateStats counts the proposals created from one template by status.
+type TemplateStats struct {
+	TemplateID primitive.ObjectID
+	Used       int64
+	ByStatus   map[string]int64
+}
+
+// Won counts proposals that were accepted, including those since contracted.
+func (s TemplateStats) Won() int64 {
+	return s.ByStatus[StatusAccepted] + s.ByStatus[StatusContracted]
+}
+
+// AcceptanceRate is the share of decided proposals that were won. It is zero
+// until a client has accepted or rejected one.
+func (s TemplateStats) AcceptanceRate() float64 {
+	decided := s.Won() + s.ByStatus[StatusRejected]
+	if decided == 0 {
+		return 0
+	}
+	return float64(s.Won()) / float64(decided)
+}
+
 type Section struct {
+	// ID addresses a proposal section across versions. Template sections
+	// have none; proposal sections get one when the proposal is created.
+	ID      string `bson:"id,omitempty"`
 	Heading string `bson:"heading"`
 	Body    string `bson:"body"`
+	// Format is how Body is written: plain, markdown or html. Bodies
+	// stored before formats existed have none and are Markdown.
+	Format string `bson:"format,omitempty"`
+	Order  int    `bson:"order"`
+}
+
+// NewSectionID returns a fresh section id.
+func NewSectionID() string {
+	return primitive.NewObjectID().Hex()
+}
+
+// WithSectionIDs returns sections with positional ids filled in for sections
+// stored before ids existed, so they can be addressed until an edit persists
+// the ids.
+func WithSectionIDs(sections []Section) []Section {
+	if sections == nil {
+		return nil
+	}
+	filled := make([]Section, len(sections))
+	for i, sec := range sections {
+		if sec.ID == "" {
+			sec.ID = fmt.Sprintf("section-%d", i+1)
+		}
+		filled[i] = sec
+	}
+	return filled
+}
+
+// ContentFromSections renders sections as the proposal's plain content: each
+// heading as a Markdown heading followed by its body.
+func ContentFromSections(sections []Section) string {
+	var sb strings.Builder
+	for i, sec := range sections {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString("## ")
+		sb.WriteString(sec.Heading)
+		if body := strings.TrimSpace(sec.Body); body != "" {
+			sb.WriteString("\n\n")
+			sb.WriteString(body)
+		}
+	}
+	return sb.String()
+}
+
+// ProposalRevision is an immutable snapshot of a proposal as it was at a
+// given version, written before every update overwrites it.
+type ProposalRevision struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	ProposalID primitive.ObjectID `bson:"proposal_id"`
+	Version    int                `bson:"version"`
+	Title      string             `bson:"title"`
+	Content    string             `bson:"content"`
+	ContentFormat string          `bson:"content_format,omitempty"`
+	Sections   []Section          `bson:"sections,omitempty"`
+	Deadline   time.Time          `bson:"deadline"`
+	Status     string             `bson:"status"`
+	Pricing    *Pricing           `bson:"pricing,omitempty"`
+	Milestones []Milestone        `bson:"milestones,omitempty"`
+	Attachments []Attachment     `bson:"attachments,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+// RevisionOf captures the versioned fields of p. CreatedAt is the time the
+// version was written, i.e. the proposal's last update.
+func RevisionOf(p *Proposal) ProposalRevision {
+	return ProposalRevision{
+		ProposalID:    p.ID,
+		Version:       p.Version,
+		Title:         p.Title,
+		Content:       p.Content,
+		ContentFormat: p.ContentFormat,
+		Sections:      p.Sections,
+		Deadline:      p.Deadline,
+		Status:        p.Status,
+		Pricing:       p.Pricing,
+		Milestones:    p.Milestones,
+		Attachments:   p.Attachments,
+		CreatedAt:     p.UpdatedAt,
+	}
 }
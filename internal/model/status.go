@@ -0,0 +1,31 @@
+package model
+
+const (
+	StatusDraft     = "draft"
+	StatusSent      = "sent"
+	StatusAccepted  = "accepted"
+	StatusRejected  = "rejected"
+	StatusWithdrawn = "withdrawn"
+	StatusExpired   = "expired"
+	// StatusContracted marks an accepted proposal whose contract has been
+	// signed in the contract service.
+	StatusContracted = "contracted"
+)
+
+// DeletedUserID replaces the id of a deleted user on archived proposals.
+const DeletedUserID = "deleted-user"
+
+const (
+	RoleFreelancer = "freelancer"
+	RoleClient     = "client"
+	RoleAdmin      = "admin"
+	// RoleSystem is used for changes made by the service itself, such as
+	// expiry and post-publish status updates.
+	RoleSystem = "system"
+)
+
+// Actor identifies who is making a change.
+type Actor struct {
+	UserID string `bson:"user_id"`
+	Role   string `bson:"role"`
+}
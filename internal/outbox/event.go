@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/kafka"
+)
+
+// eventFromOutbox builds the published form of a stored outbox event.
+func eventFromOutbox(e *model.OutboxEvent) kafka.ProposalEvent {
+	return kafka.ProposalEvent{
+		EventID:       e.ID.Hex(),
+		EventType:     e.EventType,
+		SchemaVersion: kafka.SchemaVersion,
+		OccurredAt:    e.CreatedAt,
+		ProposalID:    e.ProposalID.Hex(),
+		Actor:         kafka.Actor{UserID: e.Actor.UserID, Role: e.Actor.Role},
+		Before:        snapshotOf(e.Before),
+		After:         snapshotOf(e.After),
+	}
+}
+
+func snapshotOf(p *model.Proposal) *kafka.ProposalSnapshot {
+	if p == nil {
+		return nil
+	}
+	snapshot := &kafka.ProposalSnapshot{
+		ProposalID:    p.ID.Hex(),
+		ClientID:      p.ClientID,
+		FreelancerID:  p.FreelancerID,
+		Title:         p.Title,
+		Content:       p.Content,
+		ContentFormat: p.ContentFormat,
+		Status:        p.Status,
+		Version:       p.Version,
+		Deadline:      p.Deadline,
+		JobID:         p.JobID,
+		ContractID:    p.ContractID,
+		ArchivedAt:    p.ArchivedAt,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	if p.TemplateID != nil {
+		snapshot.TemplateID = p.TemplateID.Hex()
+		snapshot.TemplateVersion = p.TemplateVersion
+	}
+	snapshot.Pricing = pricingSnapshotOf(p.Pricing)
+	for _, m := range p.Milestones {
+		snapshot.Milestones = append(snapshot.Milestones, kafka.MilestoneSnapshot(m))
+	}
+	for _, a := range p.Attachments {
+		snapshot.Attachments = append(snapshot.Attachments, kafka.AttachmentSnapshot(a))
+	}
+	for _, sec := range p.Sections {
+		snapshot.Sections = append(snapshot.Sections, kafka.SectionSnapshot{ID: sec.ID, Heading: sec.Heading, Body: sec.Body, Format: sec.Format, Order: sec.Order})
+	}
+	return snapshot
+}
+
+func pricingSnapshotOf(p *model.Pricing) *kafka.PricingSnapshot {
+	if p == nil {
+		return nil
+	}
+	snapshot := &kafka.PricingSnapshot{
+		Currency:        p.Currency,
+		Type:            p.Type,
+		FixedPriceCents: p.FixedPriceCents,
+		HourlyRateCents: p.HourlyRateCents,
+		EstimatedHours:  p.EstimatedHours,
+		Discounts:       adjustmentSnapshotsOf(p.Discounts),
+		Taxes:           adjustmentSnapshotsOf(p.Taxes),
+		SubtotalCents:   p.SubtotalCents,
+		DiscountCents:   p.DiscountCents,
+		TaxCents:        p.TaxCents,
+		TotalCents:      p.TotalCents,
+	}
+	for _, item := range p.LineItems {
+		snapshot.LineItems = append(snapshot.LineItems, kafka.LineItemSnapshot(item))
+	}
+	return snapshot
+}
+
+func adjustmentSnapshotsOf(adjustments []model.Adjustment) []kafka.AdjustmentSnapshot {
+	var snapshots []kafka.AdjustmentSnapshot
+	for _, adj := range adjustments {
+		snapshots = append(snapshots, kafka.AdjustmentSnapshot(adj))
+	}
+	return snapshots
+}
@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/kafka"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Store is the persistence the relay needs; ProposalRepository implements it.
+type Store interface {
+	ClaimOutboxEvent(ctx context.Context, lease time.Duration) (*model.OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id primitive.ObjectID) error
+	MarkOutboxEventDelivered(ctx context.Context, id primitive.ObjectID) error
+	MarkOutboxEventFailed(ctx context.Context, id primitive.ObjectID, cause error, retryAt time.Time) error
+}
+
+// DeliveredFunc runs after an event has been published and before it is
+// marked delivered. An error retries only the callback; the event is not
+// published again.
+type DeliveredFunc func(ctx context.Context, event *model.OutboxEvent) error
+
+const (
+	// lease keeps a claimed event from being picked up by another relay
+	// while it is being published.
+	lease      = 30 * time.Second
+	minBackoff = time.Second
+	maxBackoff = 5 * time.Minute
+)
+
+// Relay publishes pending outbox events with retries, giving at-least-once
+// delivery. Consumers deduplicate on the event id.
+type Relay struct {
+	store       Store
+	publisher   kafka.Publisher
+	onDelivered DeliveredFunc
+	interval    time.Duration
+}
+
+func NewRelay(store Store, publisher kafka.Publisher, onDelivered DeliveredFunc, interval time.Duration) *Relay {
+	return &Relay{
+		store:       store,
+		publisher:   publisher,
+		onDelivered: onDelivered,
+		interval:    interval,
+	}
+}
+
+// Run polls for due events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		event, err := r.store.ClaimOutboxEvent(ctx, lease)
+		if err != nil {
+			log.Printf("outbox relay: %v", err)
+			return
+		}
+		if event == nil {
+			return
+		}
+		r.deliver(ctx, event)
+	}
+}
+
+func (r *Relay) deliver(ctx context.Context, event *model.OutboxEvent) {
+	if event.Status != model.OutboxPublished {
+		if err := r.publisher.Publish(ctx, eventFromOutbox(event)); err != nil {
+			r.retry(ctx, event, "publishing", err)
+			return
+		}
+		if r.onDelivered != nil {
+			// Record the publish before the callback so a failing callback
+			// cannot put the event on Kafka again.
+			if err := r.store.MarkOutboxEventPublished(ctx, event.ID); err != nil {
+				log.Printf("outbox relay: %v", err)
+				return
+			}
+		}
+	}
+
+	if r.onDelivered != nil {
+		if err := r.onDelivered(ctx, event); err != nil {
+			r.retry(ctx, event, "running the delivery callback for", err)
+			return
+		}
+	}
+	if err := r.store.MarkOutboxEventDelivered(ctx, event.ID); err != nil {
+		log.Printf("outbox relay: %v", err)
+	}
+}
+
+func (r *Relay) retry(ctx context.Context, event *model.OutboxEvent, step string, cause error) {
+	retryAt := time.Now().Add(backoff(event.Attempts))
+	log.Printf("outbox relay: %s %s event %s failed (attempt %d), retrying at %s: %v",
+		step, event.EventType, event.ID.Hex(), event.Attempts, retryAt.Format(time.RFC3339), cause)
+	if err := r.store.MarkOutboxEventFailed(ctx, event.ID, cause, retryAt); err != nil {
+		log.Printf("outbox relay: %v", err)
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	d := minBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,252 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/kafka"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memStore keeps outbox events in creation order and claims and settles them
+// the way the MongoDB repository does.
+type memStore struct {
+	events []*model.OutboxEvent
+	errors map[primitive.ObjectID]error
+}
+
+func newMemStore(events ...*model.OutboxEvent) *memStore {
+	for _, e := range events {
+		e.Status = model.OutboxPending
+	}
+	return &memStore{events: events, errors: make(map[primitive.ObjectID]error)}
+}
+
+func (s *memStore) ClaimOutboxEvent(ctx context.Context, lease time.Duration) (*model.OutboxEvent, error) {
+	now := time.Now()
+	blocked := make(map[primitive.ObjectID]bool)
+	for _, e := range s.events {
+		if e.Status == model.OutboxDelivered {
+			continue
+		}
+		// Only the oldest undelivered event of a proposal can be claimed.
+		if blocked[e.ProposalID] || e.NextAttemptAt.After(now) {
+			blocked[e.ProposalID] = true
+			continue
+		}
+		e.NextAttemptAt = now.Add(lease)
+		e.Attempts++
+		claimed := *e
+		return &claimed, nil
+	}
+	return nil, nil
+}
+
+func (s *memStore) MarkOutboxEventPublished(ctx context.Context, id primitive.ObjectID) error {
+	s.get(id).Status = model.OutboxPublished
+	return nil
+}
+
+func (s *memStore) MarkOutboxEventDelivered(ctx context.Context, id primitive.ObjectID) error {
+	now := time.Now()
+	e := s.get(id)
+	e.Status, e.DeliveredAt = model.OutboxDelivered, &now
+	return nil
+}
+
+func (s *memStore) MarkOutboxEventFailed(ctx context.Context, id primitive.ObjectID, cause error, retryAt time.Time) error {
+	s.errors[id] = cause
+	s.get(id).NextAttemptAt = retryAt
+	return nil
+}
+
+func (s *memStore) get(id primitive.ObjectID) *model.OutboxEvent {
+	for _, e := range s.events {
+		if e.ID == id {
+			return e
+		}
+	}
+	panic("unknown outbox event " + id.Hex())
+}
+
+// retryNow makes every event that is waiting for a retry due.
+func (s *memStore) retryNow() {
+	for _, e := range s.events {
+		e.NextAttemptAt = time.Time{}
+	}
+}
+
+func TestRelayPublishesKeyedEnvelope(t *testing.T) {
+	proposal := &model.Proposal{
+		ID:           primitive.NewObjectID(),
+		ClientID:     "client-1",
+		FreelancerID: "freelancer-1",
+		Title:        "Website redesign",
+		Status:       model.StatusSent,
+		Version:      3,
+	}
+	before := *proposal
+	before.Status, before.Version = model.StatusDraft, 2
+	event := &model.OutboxEvent{
+		ID:         primitive.NewObjectID(),
+		EventType:  "proposal.sent",
+		ProposalID: proposal.ID,
+		Actor:      model.Actor{UserID: "freelancer-1", Role: model.RoleFreelancer},
+		Before:     &before,
+		After:      proposal,
+		CreatedAt:  time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	store := newMemStore(event)
+	publisher := kafka.NewMemoryPublisher()
+
+	NewRelay(store, publisher, nil, time.Second).drain(context.Background())
+
+	if got := store.get(event.ID).Status; got != model.OutboxDelivered {
+		t.Fatalf("event status = %s, want delivered", got)
+	}
+	messages := publisher.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("published %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+
+	if string(msg.Key) != proposal.ID.Hex() {
+		t.Errorf("key = %q, want the proposal id %q", msg.Key, proposal.ID.Hex())
+	}
+	headers := make(map[string]string)
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	wantHeaders := map[string]string{
+		kafka.HeaderEventType:     "proposal.sent",
+		kafka.HeaderSchemaVersion: "2",
+		kafka.HeaderContentType:   kafka.ContentTypeJSON,
+	}
+	for key, want := range wantHeaders {
+		if headers[key] != want {
+			t.Errorf("header %s = %q, want %q", key, headers[key], want)
+		}
+	}
+
+	var envelope kafka.ProposalEvent
+	if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+		t.Fatalf("decoding envelope: %v", err)
+	}
+	if envelope.EventID != event.ID.Hex() || envelope.EventType != "proposal.sent" || envelope.SchemaVersion != kafka.SchemaVersion {
+		t.Errorf("envelope identity = %s %s v%d", envelope.EventID, envelope.EventType, envelope.SchemaVersion)
+	}
+	if envelope.ProposalID != proposal.ID.Hex() || !envelope.OccurredAt.Equal(event.CreatedAt) {
+		t.Errorf("envelope proposal %s at %s", envelope.ProposalID, envelope.OccurredAt)
+	}
+	if envelope.Actor != (kafka.Actor{UserID: "freelancer-1", Role: model.RoleFreelancer}) {
+		t.Errorf("envelope actor = %+v", envelope.Actor)
+	}
+	if envelope.Before == nil || envelope.Before.Status != model.StatusDraft || envelope.Before.Version != 2 {
+		t.Errorf("envelope before = %+v, want the draft at version 2", envelope.Before)
+	}
+	if envelope.After == nil || envelope.After.Status != model.StatusSent || envelope.After.Title != "Website redesign" {
+		t.Errorf("envelope after = %+v, want the sent proposal", envelope.After)
+	}
+}
+
+func TestRelayRetriesFailedPublish(t *testing.T) {
+	event := &model.OutboxEvent{ID: primitive.NewObjectID(), EventType: "proposal.created", ProposalID: primitive.NewObjectID()}
+	store := newMemStore(event)
+	publisher := kafka.NewMemoryPublisher()
+	publisher.Close()
+
+	NewRelay(store, publisher, nil, time.Second).drain(context.Background())
+
+	if got := store.get(event.ID); got.Status != model.OutboxPending || !got.NextAttemptAt.After(time.Now()) {
+		t.Errorf("event status %s, next attempt %s; want it pending for a later retry", got.Status, got.NextAttemptAt)
+	}
+	if store.errors[event.ID] == nil {
+		t.Errorf("publish failure not recorded")
+	}
+}
+
+func TestRelayRetriesDeliveryCallbackWithoutRepublishing(t *testing.T) {
+	event := &model.OutboxEvent{ID: primitive.NewObjectID(), EventType: "proposal.created", ProposalID: primitive.NewObjectID()}
+	store := newMemStore(event)
+	publisher := kafka.NewMemoryPublisher()
+	callbackErr := errors.New("status update failed")
+	var callbacks int
+	onDelivered := func(ctx context.Context, event *model.OutboxEvent) error {
+		callbacks++
+		return callbackErr
+	}
+	relay := NewRelay(store, publisher, onDelivered, time.Second)
+
+	relay.drain(context.Background())
+	if got := store.get(event.ID).Status; got != model.OutboxPublished || store.errors[event.ID] != callbackErr {
+		t.Fatalf("event status %s, error %v; want it published with the callback to retry", got, store.errors[event.ID])
+	}
+
+	callbackErr = nil
+	store.retryNow()
+	relay.drain(context.Background())
+
+	if got := store.get(event.ID).Status; got != model.OutboxDelivered {
+		t.Errorf("event status = %s, want delivered once the callback succeeds", got)
+	}
+	if callbacks != 2 {
+		t.Errorf("callback ran %d times, want 2", callbacks)
+	}
+	if n := len(publisher.Events()); n != 1 {
+		t.Errorf("published %d times, want once", n)
+	}
+}
+
+// failingPublisher fails every publish of the given events.
+type failingPublisher struct {
+	*kafka.MemoryPublisher
+	fail map[string]bool
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, event kafka.ProposalEvent) error {
+	if p.fail[event.EventID] {
+		return errors.New("broker unavailable")
+	}
+	return p.MemoryPublisher.Publish(ctx, event)
+}
+
+func TestRelayKeepsProposalEventsInOrder(t *testing.T) {
+	proposalID, otherID := primitive.NewObjectID(), primitive.NewObjectID()
+	first := &model.OutboxEvent{ID: primitive.NewObjectID(), EventType: "proposal.created", ProposalID: proposalID}
+	second := &model.OutboxEvent{ID: primitive.NewObjectID(), EventType: "proposal.sent", ProposalID: proposalID}
+	other := &model.OutboxEvent{ID: primitive.NewObjectID(), EventType: "proposal.created", ProposalID: otherID}
+	store := newMemStore(first, second, other)
+	publisher := &failingPublisher{MemoryPublisher: kafka.NewMemoryPublisher(), fail: map[string]bool{first.ID.Hex(): true}}
+	relay := NewRelay(store, publisher, nil, time.Second)
+
+	relay.drain(context.Background())
+
+	if got := store.get(second.ID); got.Status != model.OutboxPending || got.Attempts != 0 {
+		t.Fatalf("second event status %s after %d attempts; want it waiting for the first", got.Status, got.Attempts)
+	}
+	if got := store.get(other.ID).Status; got != model.OutboxDelivered {
+		t.Errorf("other proposal's event status = %s, want delivered", got)
+	}
+
+	publisher.fail = nil
+	store.retryNow()
+	relay.drain(context.Background())
+
+	var order []string
+	for _, e := range publisher.Events() {
+		order = append(order, e.EventID)
+	}
+	want := []string{other.ID.Hex(), first.ID.Hex(), second.ID.Hex()}
+	if len(order) != len(want) {
+		t.Fatalf("published %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("published %v, want %v", order, want)
+		}
+	}
+}
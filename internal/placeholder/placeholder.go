@@ -0,0 +1,45 @@
+// Package placeholder finds and substitutes {{name}} placeholders in
+// template text.
+package placeholder
+
+import (
+	"regexp"
+	"strings"
+)
+
+var pattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidName reports whether name can be used as a placeholder.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Names returns the distinct placeholder names in text, in order of first use.
+func Names(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// Render replaces every placeholder that has a value. Placeholders without a
+// value are left as they are.
+func Render(text string, values map[string]string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := pattern.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
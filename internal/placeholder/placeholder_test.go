@@ -0,0 +1,64 @@
+package placeholder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"no placeholders", nil},
+		{"Hi {{client}}, {{ client }} again", []string{"client"}},
+		{"{{b}} then {{a}} then {{b}}", []string{"b", "a"}},
+		{"{{_rate2}}", []string{"_rate2"}},
+		// Not names, so not placeholders.
+		{"{{2fast}} {{first name}} {{}} {client}", nil},
+	}
+	for _, tt := range tests {
+		if got := Names(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Names(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	values := map[string]string{"client": "Acme", "rate": "120", "empty": ""}
+	tests := []struct {
+		name, text, want string
+	}{
+		{"plain text", "nothing to do", "nothing to do"},
+		{"spacing inside braces", "Hi {{client}} / {{ client }}", "Hi Acme / Acme"},
+		{"empty value", "[{{empty}}]", "[]"},
+		{"unknown placeholder is kept", "{{client}} owes {{amount}}", "Acme owes {{amount}}"},
+		{"invalid name is kept", "{{2fast}} {{first name}}", "{{2fast}} {{first name}}"},
+		{"extra braces stay literal", "{{{client}}}", "{Acme}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.text, values); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderDoesNotExpandValues(t *testing.T) {
+	values := map[string]string{"a": "{{b}}", "b": "B", "html": "<b>&</b>"}
+	if got := Render("{{a}} {{html}}", values); got != "{{b}} <b>&</b>" {
+		t.Errorf("Render = %q, want values inserted literally", got)
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"client": true, "_x": true, "rate_2": true,
+		"": false, "2fast": false, "first name": false, "a-b": false,
+	} {
+		if got := ValidName(name); got != want {
+			t.Errorf("ValidName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
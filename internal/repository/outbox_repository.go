@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// deliveredOutboxTTL is how long a delivered outbox event is kept before its
+// TTL index removes it.
+const deliveredOutboxTTL = 7 * 24 * time.Hour
+
+// outboxClaimCandidates bounds how many due events ClaimOutboxEvent tries
+// before giving up to concurrent relays.
+const outboxClaimCandidates = 16
+
+// withTransaction runs fn in a multi-document transaction so a proposal change
+// and the events describing it are committed together.
+func (r *ProposalRepository) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) (interface{}, error)) (interface{}, error) {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	return session.WithTransaction(ctx, fn)
+}
+
+// insertOutboxEvents completes and stores events for a proposal change.
+func (r *ProposalRepository) insertOutboxEvents(ctx context.Context, events []model.OutboxEvent, before, after *model.Proposal) error {
+	if len(events) == 0 {
+		return nil
+	}
+	collection := r.client.Database(r.database).Collection("outbox")
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		event.ID = primitive.NewObjectID()
+		event.ProposalID = after.ID
+		event.Before = before
+		event.After = after
+		event.Status = model.OutboxPending
+		event.NextAttemptAt = now
+		event.CreatedAt = now
+		docs = append(docs, event)
+	}
+
+	if _, err := collection.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to write outbox events: %w", err)
+	}
+	return nil
+}
+
+// ClaimOutboxEvent leases the oldest due event that is pending or still
+// waiting for its delivery callback, so that concurrent relays do not handle
+// it at the same time. Only the oldest undelivered event of each proposal can
+// be claimed, so a proposal's events reach Kafka in the order they were
+// written even while an earlier one is waiting for a retry. It returns nil
+// when nothing is due.
+func (r *ProposalRepository) ClaimOutboxEvent(ctx context.Context, lease time.Duration) (*model.OutboxEvent, error) {
+	collection := r.client.Database(r.database).Collection("outbox")
+	now := time.Now()
+	undelivered := bson.M{"$in": []string{model.OutboxPending, model.OutboxPublished}}
+
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": undelivered}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             "$proposal_id",
+			"event_id":        bson.M{"$first": "$_id"},
+			"next_attempt_at": bson.M{"$first": "$next_attempt_at"},
+		}}},
+		{{Key: "$match", Value: bson.M{"next_attempt_at": bson.M{"$lte": now}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "event_id", Value: 1}}}},
+		{{Key: "$limit", Value: outboxClaimCandidates}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due outbox events: %w", err)
+	}
+	var heads []struct {
+		EventID primitive.ObjectID `bson:"event_id"`
+	}
+	if err := cursor.All(ctx, &heads); err != nil {
+		return nil, fmt.Errorf("failed to decode due outbox events: %w", err)
+	}
+
+	for _, head := range heads {
+		// Another relay may have claimed the event since the lookup above.
+		result := collection.FindOneAndUpdate(
+			ctx,
+			bson.M{"_id": head.EventID, "status": undelivered, "next_attempt_at": bson.M{"$lte": now}},
+			bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}, "$inc": bson.M{"attempts": 1}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		)
+
+		var event model.OutboxEvent
+		if err := result.Decode(&event); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to claim outbox event: %w", err)
+		}
+		return &event, nil
+	}
+	return nil, nil
+}
+
+// MarkOutboxEventPublished records that an event is on Kafka while its
+// delivery callback is still to run. The event stays claimable until
+// MarkOutboxEventDelivered, but is not published again.
+func (r *ProposalRepository) MarkOutboxEventPublished(ctx context.Context, id primitive.ObjectID) error {
+	collection := r.client.Database(r.database).Collection("outbox")
+	now := time.Now()
+
+	_, err := collection.UpdateByID(ctx, id, bson.M{
+		"$set":   bson.M{"status": model.OutboxPublished, "published_at": now},
+		"$unset": bson.M{"last_error": ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event %s published: %w", id.Hex(), err)
+	}
+	return nil
+}
+
+func (r *ProposalRepository) MarkOutboxEventDelivered(ctx context.Context, id primitive.ObjectID) error {
+	collection := r.client.Database(r.database).Collection("outbox")
+	now := time.Now()
+
+	_, err := collection.UpdateByID(ctx, id, bson.M{
+		"$set":   bson.M{"status": model.OutboxDelivered, "delivered_at": now},
+		"$unset": bson.M{"last_error": ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event %s delivered: %w", id.Hex(), err)
+	}
+	return nil
+}
+
+func (r *ProposalRepository) MarkOutboxEventFailed(ctx context.Context, id primitive.ObjectID, cause error, retryAt time.Time) error {
+	collection := r.client.Database(r.database).Collection("outbox")
+
+	_, err := collection.UpdateByID(ctx, id, bson.M{
+		"$set": bson.M{"last_error": cause.Error(), "next_attempt_at": retryAt},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record outbox failure for %s: %w", id.Hex(), err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 	"log"
@@ -12,16 +13,31 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// VersionConflictError is returned by UpdateProposal when the stored version
+// no longer matches the version the caller based its edit on.
+type VersionConflictError struct {
+	ProposalID     string
+	CurrentVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("proposal %s has been modified: current version is %d", e.ProposalID, e.CurrentVersion)
+}
+
 type ProposalRepository struct {
-	client *mongo.Client
+	client   *mongo.Client
+	database string
 }
 
-func NewProposalRepository(client *mongo.Client) *ProposalRepository {
-	return &ProposalRepository{client: client}
+// NewProposalRepository stores everything in the named database, the same
+// one the GridFS attachment store uses.
+func NewProposalRepository(client *mongo.Client, database string) *ProposalRepository {
+	return &ProposalRepository{client: client, database: database}
 }
 
-func (r *ProposalRepository) CreateProposal(ctx context.Context, proposal model.Proposal) (*model.Proposal, error) {
-	collection := r.client.Database("freelanceX_proposals").Collection("proposals")
+// CreateProposal inserts the proposal and its outbox events atomically.
+func (r *ProposalRepository) CreateProposal(ctx context.Context, proposal model.Proposal, events ...model.OutboxEvent) (*model.Proposal, error) {
+	collection := r.client.Database(r.database).Collection("proposals")
 
 	log.Printf("Repository - About to save proposal: %+v", proposal)
 
@@ -29,23 +45,30 @@ func (r *ProposalRepository) CreateProposal(ctx context.Context, proposal model.
 	proposal.CreatedAt = time.Now()
 	proposal.UpdatedAt = time.Now()
 
-	_, err := collection.InsertOne(ctx, proposal)
+	_, err := r.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := collection.InsertOne(sc, proposal); err != nil {
+			return nil, fmt.Errorf("failed to create proposal: %w", err)
+		}
+		return nil, r.insertOutboxEvents(sc, events, nil, &proposal)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create proposal: %w", err)
+		return nil, err
 	}
 
 	return &proposal, nil
 }
 
+// GetProposalByID returns a proposal that has not been archived; an archived
+// proposal is reported like a missing one.
 func (r *ProposalRepository) GetProposalByID(ctx context.Context, proposalID string) (*model.Proposal, error) {
-	collection := r.client.Database("freelanceX_proposals").Collection("proposals")
+	collection := r.client.Database(r.database).Collection("proposals")
 	objID, err := primitive.ObjectIDFromHex(proposalID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid proposal ID: %w", err)
 	}
 
 	var proposal model.Proposal
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&proposal)
+	err = collection.FindOne(ctx, bson.M{"_id": objID, "archived_at": bson.M{"$exists": false}}).Decode(&proposal)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("proposal with ID %s not found: %w", proposalID, err)
@@ -56,53 +79,187 @@ func (r *ProposalRepository) GetProposalByID(ctx context.Context, proposalID str
 	return &proposal, nil
 }
 
-func (r *ProposalRepository) UpdateProposal(ctx context.Context, proposalID string, update model.Proposal) (*model.Proposal, error) {
-	collection := r.client.Database("freelanceX_proposals").Collection("proposals")
+// UpdateProposal sets only the listed fields from update, snapshots the
+// superseded version and records the outbox events in a single transaction.
+func (r *ProposalRepository) UpdateProposal(ctx context.Context, proposalID string, update model.Proposal, fields []string, events ...model.OutboxEvent) (*model.Proposal, error) {
+	updateFields := bson.M{
+		"updated_at": time.Now(),
+	}
+
+	for _, field := range fields {
+		switch field {
+		case model.FieldTitle:
+			updateFields[field] = update.Title
+		case model.FieldContent:
+			updateFields[field] = update.Content
+			updateFields["content_format"] = update.ContentFormat
+		case model.FieldStatus:
+			updateFields[field] = update.Status
+		case model.FieldDeadline:
+			updateFields[field] = update.Deadline
+		case model.FieldSections:
+			updateFields[field] = update.Sections
+		case model.FieldContractID:
+			updateFields[field] = update.ContractID
+		case model.FieldPricing:
+			updateFields[field] = update.Pricing
+		case model.FieldMilestones:
+			updateFields[field] = update.Milestones
+		case model.FieldAttachments:
+			updateFields[field] = update.Attachments
+		default:
+			return nil, fmt.Errorf("cannot update proposal field %q", field)
+		}
+	}
+
+	return r.applyUpdate(ctx, proposalID, update.Version, updateFields, events)
+}
+
+// applyUpdate sets fields on a proposal, bumps its version, snapshots the
+// superseded version and records the outbox events in one transaction.
+func (r *ProposalRepository) applyUpdate(ctx context.Context, proposalID string, version int, updateFields bson.M, events []model.OutboxEvent) (*model.Proposal, error) {
+	collection := r.client.Database(r.database).Collection("proposals")
 	objID, err := primitive.ObjectIDFromHex(proposalID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid proposal ID: %w", err)
 	}
 
-	updateFields := bson.M{
-		"title":        update.Title,
-		"content":      update.Content,
-		"status":       update.Status,
-		"updated_at":   time.Now(),
+	// A non-zero version makes the update conditional on the stored version,
+	// so concurrent editors cannot silently overwrite each other.
+	filter := bson.M{"_id": objID}
+	if version > 0 {
+		filter["version"] = version
 	}
 
-	if !update.Deadline.IsZero() {
-		updateFields["deadline"] = update.Deadline
+	result, err := r.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		updateResult := collection.FindOneAndUpdate(
+			sc,
+			filter,
+			bson.M{"$set": updateFields, "$inc": bson.M{"version": 1}},
+			options.FindOneAndUpdate().SetReturnDocument(options.Before),
+		)
+
+		var previous model.Proposal
+		if err := updateResult.Decode(&previous); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) && version > 0 {
+				current, getErr := r.GetProposalByID(sc, proposalID)
+				if getErr != nil {
+					return nil, getErr
+				}
+				return nil, &VersionConflictError{ProposalID: proposalID, CurrentVersion: current.Version}
+			}
+			return nil, fmt.Errorf("failed to decode updated proposal: %w", err)
+		}
+
+		revision := model.RevisionOf(&previous)
+		if _, err := r.client.Database(r.database).Collection("proposal_revisions").InsertOne(sc, revision); err != nil {
+			return nil, fmt.Errorf("failed to save proposal revision %d: %w", previous.Version, err)
+		}
+
+		updated, err := r.GetProposalByID(sc, proposalID)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.insertOutboxEvents(sc, events, &previous, updated); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	updateResult := collection.FindOneAndUpdate(
-		ctx,
-		bson.M{"_id": objID},
-		bson.M{"$set": updateFields, "$inc": bson.M{"version": 1}},
-		options.FindOneAndUpdate().SetReturnDocument(options.After),
-	)
+	return result.(*model.Proposal), nil
+}
 
-	var updatedProposal model.Proposal
-	if err := updateResult.Decode(&updatedProposal); err != nil {
-		return nil, fmt.Errorf("failed to decode updated proposal: %w", err)
+func (r *ProposalRepository) GetProposalRevisions(ctx context.Context, proposalID string) ([]*model.ProposalRevision, error) {
+	collection := r.client.Database(r.database).Collection("proposal_revisions")
+	objID, err := primitive.ObjectIDFromHex(proposalID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal ID: %w", err)
 	}
 
-	return &updatedProposal, nil
+	cursor, err := collection.Find(ctx, bson.M{"proposal_id": objID}, options.Find().SetSort(bson.D{{Key: "version", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions for proposal %s: %w", proposalID, err)
+	}
+	defer cursor.Close(ctx)
+
+	var revisions []*model.ProposalRevision
+	for cursor.Next(ctx) {
+		var revision model.ProposalRevision
+		if err := cursor.Decode(&revision); err != nil {
+			return nil, fmt.Errorf("failed to decode revision: %w", err)
+		}
+		revisions = append(revisions, &revision)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return revisions, nil
 }
 
-func (r *ProposalRepository) GetProposals(ctx context.Context, filters map[string]interface{}, skip, limit int64) ([]*model.Proposal, error) {
-	collection := r.client.Database("freelanceX_proposals").Collection("proposals")
+func (r *ProposalRepository) GetProposalRevision(ctx context.Context, proposalID string, version int) (*model.ProposalRevision, error) {
+	collection := r.client.Database(r.database).Collection("proposal_revisions")
+	objID, err := primitive.ObjectIDFromHex(proposalID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal ID: %w", err)
+	}
 
-	filter := bson.M{}
-	for key, value := range filters {
-		filter[key] = value
+	var revision model.ProposalRevision
+	err = collection.FindOne(ctx, bson.M{"proposal_id": objID, "version": version}).Decode(&revision)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("revision %d of proposal %s not found: %w", version, proposalID, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve revision: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// GetProposals returns one page of the proposals matching the filter together
+// with the number of proposals that match in total. The total is a separate
+// CountDocuments, so every page costs two queries.
+func (r *ProposalRepository) GetProposals(ctx context.Context, proposalFilter model.ProposalFilter, page model.ProposalPage) ([]*model.Proposal, int64, error) {
+	collection := r.client.Database(r.database).Collection("proposals")
+
+	filter := proposalFilterQuery(proposalFilter)
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count proposals: %w", err)
+	}
+
+	direction, after := 1, "$gt"
+	if page.Descending {
+		direction, after = -1, "$lt"
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: page.SortBy, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(page.Limit)
+
+	if page.After != nil {
+		var value interface{} = page.After.Time
+		if page.SortBy == model.SortStatus {
+			value = page.After.Status
+		}
+		// Everything past the cursor: a later sort value, or the same value
+		// and a later id.
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{page.SortBy: bson.M{after: value}},
+			bson.M{page.SortBy: value, "_id": bson.M{after: page.After.ID}},
+		}}}}
+	} else if page.Skip > 0 {
+		findOptions.SetSkip(page.Skip)
 	}
-	findOptions := options.Find()
-	findOptions.SetSkip(skip)
-	findOptions.SetLimit(limit)
 
 	cursor, err := collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query proposals: %w", err)
+		return nil, 0, fmt.Errorf("failed to query proposals: %w", err)
 	}
 	defer cursor.Close(ctx)
 
@@ -110,21 +267,161 @@ func (r *ProposalRepository) GetProposals(ctx context.Context, filters map[strin
 	for cursor.Next(ctx) {
 		var proposal model.Proposal
 		if err := cursor.Decode(&proposal); err != nil {
-			return nil, fmt.Errorf("failed to decode proposal: %w", err)
+			return nil, 0, fmt.Errorf("failed to decode proposal: %w", err)
 		}
 		proposals = append(proposals, &proposal)
 	}
 
 	if err := cursor.Err(); err != nil {
-		return nil, fmt.Errorf("cursor error: %w", err)
+		return nil, 0, fmt.Errorf("cursor error: %w", err)
 	}
 
-	return proposals, nil
+	return proposals, total, nil
+}
+
+// proposalFilterQuery translates a filter field by field, so only the fields
+// and operators chosen here can reach the query.
+func proposalFilterQuery(f model.ProposalFilter) bson.M {
+	query := bson.M{}
+	if !f.IncludeArchived {
+		query["archived_at"] = bson.M{"$exists": false}
+	}
+	if f.ClientID != "" {
+		query["client_id"] = f.ClientID
+	}
+	if f.FreelancerID != "" {
+		query["freelancer_id"] = f.FreelancerID
+	}
+	if len(f.Statuses) > 0 {
+		query["status"] = bson.M{"$in": f.Statuses}
+	}
+	if f.TemplateID != nil {
+		query["template_id"] = *f.TemplateID
+	}
+	addTimeRange(query, "created_at", f.CreatedAfter, f.CreatedBefore)
+	addTimeRange(query, "updated_at", f.UpdatedAfter, f.UpdatedBefore)
+	addTimeRange(query, "deadline", f.DeadlineAfter, f.DeadlineBefore)
+	// Proposals without a deadline store the zero time; keep them out of
+	// deadline ranges.
+	if bounds, ok := query["deadline"].(bson.M); ok && bounds["$gte"] == nil {
+		bounds["$gt"] = time.Time{}
+	}
+
+	if f.Currency != "" {
+		query["pricing.currency"] = f.Currency
+	}
+	total := bson.M{}
+	if f.MinTotalCents != nil {
+		total["$gte"] = *f.MinTotalCents
+	}
+	if f.MaxTotalCents != nil {
+		total["$lte"] = *f.MaxTotalCents
+	}
+	if len(total) > 0 {
+		query["pricing.total_cents"] = total
+	}
+	return query
+}
+
+func addTimeRange(query bson.M, field string, after, before time.Time) {
+	bounds := bson.M{}
+	if !after.IsZero() {
+		bounds["$gte"] = after
+	}
+	if !before.IsZero() {
+		bounds["$lt"] = before
+	}
+	if len(bounds) > 0 {
+		query[field] = bounds
+	}
+}
+
+// ListProposalsByTemplate returns one page of the proposals created from a
+// template, newest first, along with their total count.
+func (r *ProposalRepository) ListProposalsByTemplate(ctx context.Context, templateID primitive.ObjectID, skip, limit int64) ([]*model.Proposal, int64, error) {
+	collection := r.client.Database(r.database).Collection("proposals")
+	filter := bson.M{"template_id": templateID}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count proposals for template: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query proposals for template: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var proposals []*model.Proposal
+	if err := cursor.All(ctx, &proposals); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode proposals: %w", err)
+	}
+
+	return proposals, total, nil
+}
+
+// GetTemplateStats counts a freelancer's template-based proposals per template
+// and status. A non-nil templateID restricts the result to that template.
+func (r *ProposalRepository) GetTemplateStats(ctx context.Context, freelancerID string, templateID *primitive.ObjectID) ([]model.TemplateStats, error) {
+	collection := r.client.Database(r.database).Collection("proposals")
+
+	match := bson.M{"freelancer_id": freelancerID, "template_id": bson.M{"$exists": true}}
+	if templateID != nil {
+		match["template_id"] = *templateID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"template_id": "$template_id", "status": "$status"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.template_id", Value: 1}}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate template stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			TemplateID primitive.ObjectID `bson:"template_id"`
+			Status     string             `bson:"status"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode template stats: %w", err)
+	}
+
+	var stats []model.TemplateStats
+	index := make(map[primitive.ObjectID]int)
+	for _, row := range rows {
+		i, ok := index[row.ID.TemplateID]
+		if !ok {
+			i = len(stats)
+			index[row.ID.TemplateID] = i
+			stats = append(stats, model.TemplateStats{TemplateID: row.ID.TemplateID, ByStatus: map[string]int64{}})
+		}
+		stats[i].Used += row.Count
+		stats[i].ByStatus[row.ID.Status] += row.Count
+	}
+
+	return stats, nil
 }
 
 func (r *ProposalRepository) SaveTemplate(ctx context.Context, template model.Template) (*model.Template, error) {
-	collection := r.client.Database("freelanceX_proposals").Collection("templates")
+	collection := r.client.Database(r.database).Collection("templates")
 	template.ID = primitive.NewObjectID()
+	template.Version = 1
 	template.CreatedAt = time.Now()
 	template.UpdatedAt = time.Now()
 
@@ -138,7 +435,7 @@ func (r *ProposalRepository) SaveTemplate(ctx context.Context, template model.Te
 
 func (r *ProposalRepository) GetTemplateByID(ctx context.Context, id primitive.ObjectID) (*model.Template, error) {
 	var template model.Template
-	collection := r.client.Database("freelanceX_proposals").Collection("templates")
+	collection := r.client.Database(r.database).Collection("templates")
 	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&template)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find template: %w", err)
@@ -146,33 +443,136 @@ func (r *ProposalRepository) GetTemplateByID(ctx context.Context, id primitive.O
 	return &template, nil
 }
 
-func (r *ProposalRepository) GetTemplatesForFreelancer(ctx context.Context, freelancerID string) ([]*model.Template, error) {
-	collection := r.client.Database("freelanceX_proposals").Collection("templates")
+// GetTemplatesForFreelancer returns one page of a freelancer's templates,
+// newest first, together with the total number of templates they have.
+// Soft-deleted templates are left out.
+func (r *ProposalRepository) GetTemplatesForFreelancer(ctx context.Context, freelancerID string, skip, limit int64) ([]*model.Template, int64, error) {
+	collection := r.client.Database(r.database).Collection("templates")
+	filter := bson.M{"owner_id": freelancerID, "deleted_at": bson.M{"$exists": false}}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count templates for freelancer %s: %w", freelancerID, err)
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
 
 	var templates []*model.Template
-	cursor, err := collection.Find(ctx, bson.M{"owner_id": freelancerID})
+	cursor, err := collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve templates for freelancer %s: %w", freelancerID, err)
+		return nil, 0, fmt.Errorf("failed to retrieve templates for freelancer %s: %w", freelancerID, err)
 	}
 	defer cursor.Close(ctx)
 
 	for cursor.Next(ctx) {
 		var template model.Template
 		if err := cursor.Decode(&template); err != nil {
-			return nil, fmt.Errorf("failed to decode template: %w", err)
+			return nil, 0, fmt.Errorf("failed to decode template: %w", err)
 		}
 		templates = append(templates, &template)
 	}
 
 	if err := cursor.Err(); err != nil {
-		return nil, fmt.Errorf("cursor iteration error: %w", err)
+		return nil, 0, fmt.Errorf("cursor iteration error: %w", err)
 	}
 
-	return templates, nil
+	return templates, total, nil
+}
+
+// UpdateTemplate replaces a live template's title, description and sections
+// and bumps its version. A non-zero version makes the update conditional on
+// the stored version; mongo.ErrNoDocuments is returned when nothing matched.
+func (r *ProposalRepository) UpdateTemplate(ctx context.Context, id primitive.ObjectID, update model.Template, version int) (*model.Template, error) {
+	collection := r.client.Database(r.database).Collection("templates")
+
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}
+	if version > 0 {
+		filter["version"] = version
+	}
+
+	result := collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{
+			"$set": bson.M{
+				"title":       update.Title,
+				"description": update.Description,
+				"sections":    update.Sections,
+				"variables":   update.Variables,
+				"updated_at":  time.Now(),
+			},
+			"$inc": bson.M{"version": 1},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
+	var template model.Template
+	if err := result.Decode(&template); err != nil {
+		return nil, fmt.Errorf("failed to update template %s: %w", id.Hex(), err)
+	}
+	return &template, nil
+}
+
+// DeleteTemplate soft-deletes a template; mongo.ErrNoDocuments is returned if
+// it does not exist or is already deleted.
+func (r *ProposalRepository) DeleteTemplate(ctx context.Context, id primitive.ObjectID) error {
+	collection := r.client.Database(r.database).Collection("templates")
+	now := time.Now()
+
+	result, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete template %s: %w", id.Hex(), err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("template %s not found: %w", id.Hex(), mongo.ErrNoDocuments)
+	}
+	return nil
+}
+
+// GetBrandingProfile returns a freelancer's branding profile, or
+// mongo.ErrNoDocuments if they have not saved one.
+func (r *ProposalRepository) GetBrandingProfile(ctx context.Context, freelancerID string) (*model.BrandingProfile, error) {
+	var profile model.BrandingProfile
+	collection := r.client.Database(r.database).Collection("branding_profiles")
+	err := collection.FindOne(ctx, bson.M{"_id": freelancerID}).Decode(&profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find branding profile for freelancer %s: %w", freelancerID, err)
+	}
+	return &profile, nil
+}
+
+// SaveBrandingProfile creates or replaces a freelancer's branding profile. It
+// returns the saved profile and the one it replaced, which is nil if there
+// was none.
+func (r *ProposalRepository) SaveBrandingProfile(ctx context.Context, profile model.BrandingProfile) (*model.BrandingProfile, *model.BrandingProfile, error) {
+	collection := r.client.Database(r.database).Collection("branding_profiles")
+	profile.UpdatedAt = time.Now()
+
+	var previous model.BrandingProfile
+	err := collection.FindOneAndReplace(
+		ctx,
+		bson.M{"_id": profile.FreelancerID},
+		profile,
+		options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.Before),
+	).Decode(&previous)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &profile, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to save branding profile for freelancer %s: %w", profile.FreelancerID, err)
+	}
+	return &profile, &previous, nil
 }
 
 func (r *ProposalRepository) EnsureIndexes(ctx context.Context) error {
-	collection := r.client.Database("freelanceX_proposals").Collection("proposals")
+	collection := r.client.Database(r.database).Collection("proposals")
 
 	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		{
@@ -187,35 +587,122 @@ func (r *ProposalRepository) EnsureIndexes(ctx context.Context) error {
 		 Keys: bson.D{{Key: "status", Value: 1}},
 		 Options: options.Index().SetName("status_index"),
 		},
+		{
+			Keys:    bson.D{{Key: "job_id", Value: 1}},
+			Options: options.Index().SetName("job_id_index").SetSparse(true),
+		},
+		{
+			Keys:    bson.D{{Key: "template_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("template_id_index").SetSparse(true),
+		},
+		// Listing sort orders; each ends in _id to match the page cursor.
+		{
+			Keys:    bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("updated_at_listing_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("created_at_listing_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "deadline", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("deadline_listing_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("status_listing_index"),
+		},
 	})
 
 	if err != nil {
 		return fmt.Errorf("failed to create indexes: %w", err)
 	}
 
+	revisions := r.client.Database(r.database).Collection("proposal_revisions")
+	_, err = revisions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "proposal_id", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetName("proposal_version_index").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create revision indexes: %w", err)
+	}
+
+	outbox := r.client.Database(r.database).Collection("outbox")
+	_, err = outbox.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+			Options: options.Index().SetName("outbox_pending_index"),
+		},
+		{
+			// Only delivered events carry delivered_at, so MongoDB removes
+			// them once consumers have had time to catch up.
+			Keys:    bson.D{{Key: "delivered_at", Value: 1}},
+			Options: options.Index().SetName("outbox_delivered_ttl_index").SetExpireAfterSeconds(int32(deliveredOutboxTTL / time.Second)),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create outbox indexes: %w", err)
+	}
+
 	return nil
 }
 
-func (r *ProposalRepository) ExpireProposals(ctx context.Context) error {
-	collection := r.client.Database("freelanceX_proposals").Collection("proposals")
-
-	filter := bson.M{
-		"status": bson.M{"$in": []string{"draft", "sent"}},
-		"deadline": bson.M{"$lt": time.Now()},
+// ArchiveProposal hides a proposal whose party has been deleted, replacing
+// that party's id with model.DeletedUserID.
+func (r *ProposalRepository) ArchiveProposal(ctx context.Context, proposal *model.Proposal, deletedUserID string, events ...model.OutboxEvent) (*model.Proposal, error) {
+	updateFields := bson.M{
+		"archived_at": time.Now(),
+		"updated_at":  time.Now(),
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"status": "expired",
-			"updated_at": time.Now(),
-		},
+	if proposal.ClientID == deletedUserID {
+		updateFields["client_id"] = model.DeletedUserID
+	}
+	if proposal.FreelancerID == deletedUserID {
+		updateFields["freelancer_id"] = model.DeletedUserID
 	}
 
-	_, err := collection.UpdateMany(ctx, filter, update)
+	return r.applyUpdate(ctx, proposal.ID.Hex(), proposal.Version, updateFields, events)
+}
+
+// GetOpenProposalsForJob returns the draft and sent proposals made for a job.
+func (r *ProposalRepository) GetOpenProposalsForJob(ctx context.Context, jobID string) ([]*model.Proposal, error) {
+	return r.findProposals(ctx, bson.M{
+		"job_id": jobID,
+		"status": bson.M{"$in": []string{model.StatusDraft, model.StatusSent}},
+	})
+}
+
+// GetActiveProposalsForUser returns the unarchived proposals a user is a
+// party to.
+func (r *ProposalRepository) GetActiveProposalsForUser(ctx context.Context, userID string) ([]*model.Proposal, error) {
+	return r.findProposals(ctx, bson.M{
+		"$or":         []bson.M{{"client_id": userID}, {"freelancer_id": userID}},
+		"archived_at": bson.M{"$exists": false},
+	})
+}
+
+func (r *ProposalRepository) findProposals(ctx context.Context, filter bson.M) ([]*model.Proposal, error) {
+	collection := r.client.Database(r.database).Collection("proposals")
+
+	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
-		return fmt.Errorf("failed to expire proposals: %w", err)
+		return nil, fmt.Errorf("failed to query proposals: %w", err)
 	}
+	defer cursor.Close(ctx)
 
-	return nil
+	var proposals []*model.Proposal
+	if err := cursor.All(ctx, &proposals); err != nil {
+		return nil, fmt.Errorf("failed to decode proposals: %w", err)
+	}
+
+	return proposals, nil
 }
 
+// GetProposalsDueForExpiry returns draft and sent proposals whose deadline
+// has passed. Proposals without a deadline never expire.
+func (r *ProposalRepository) GetProposalsDueForExpiry(ctx context.Context, now time.Time) ([]*model.Proposal, error) {
+	return r.findProposals(ctx, bson.M{
+		"status": bson.M{"$in": []string{"draft", "sent"}},
+		"deadline": bson.M{"$gt": time.Time{}, "$lt": now},
+	})
+}
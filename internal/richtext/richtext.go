@@ -0,0 +1,148 @@
+// Package richtext validates proposal bodies written as plain text, Markdown
+// or HTML and renders them as HTML that is safe to embed in a page.
+package richtext
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// DefaultFormat is the format of bodies stored without one; the frontend has
+// always rendered those as Markdown.
+const DefaultFormat = FormatMarkdown
+
+// MaxBodyBytes bounds a single body.
+const MaxBodyBytes = 100_000
+
+// Formats lists the supported body formats.
+func Formats() []string {
+	return []string{FormatPlain, FormatMarkdown, FormatHTML}
+}
+
+// ValidFormat reports whether format is a supported body format. The empty
+// format stands for DefaultFormat.
+func ValidFormat(format string) bool {
+	switch format {
+	case "", FormatPlain, FormatMarkdown, FormatHTML:
+		return true
+	}
+	return false
+}
+
+// Sanitize checks a body written in format and returns the source to store.
+// HTML is sanitized with the same policy used for rendering, so what is
+// stored is what is shown; plain text and Markdown are kept as written and
+// made safe when rendered.
+func Sanitize(format, body string) (string, error) {
+	if !ValidFormat(format) {
+		return "", fmt.Errorf("unknown body format %q; use %s", format, strings.Join(Formats(), ", "))
+	}
+	if !utf8.ValidString(body) {
+		return "", fmt.Errorf("body is not valid UTF-8")
+	}
+	if len(body) > MaxBodyBytes {
+		return "", fmt.Errorf("body is larger than %d bytes", MaxBodyBytes)
+	}
+	if format == FormatHTML {
+		return strings.TrimSpace(policy.Sanitize(body)), nil
+	}
+	return body, nil
+}
+
+// ToHTML renders a body as sanitized HTML.
+func ToHTML(format, body string) string {
+	if strings.TrimSpace(body) == "" {
+		return ""
+	}
+	switch format {
+	case FormatPlain:
+		return plainToHTML(body)
+	case FormatHTML:
+		return policy.Sanitize(body)
+	default:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(body), &buf); err != nil {
+			return plainToHTML(body)
+		}
+		return policy.Sanitize(buf.String())
+	}
+}
+
+// PlainText returns the text of a body without markup, for output that
+// cannot show HTML. Markdown is returned as written.
+func PlainText(format, body string) string {
+	if format != FormatHTML {
+		return body
+	}
+	return html.UnescapeString(textPolicy.Sanitize(blockEnd.ReplaceAllString(body, "$0\n")))
+}
+
+var blockEnd = regexp.MustCompile(`(?i)(<br\s*/?>|</(p|div|li|h[1-6]|tr|blockquote|pre)>)`)
+
+// plainToHTML escapes text and keeps its paragraphs and line breaks.
+func plainToHTML(text string) string {
+	var sb strings.Builder
+	for _, block := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		sb.WriteString("<p>")
+		sb.WriteString(strings.ReplaceAll(html.EscapeString(block), "\n", "<br>\n"))
+		sb.WriteString("</p>\n")
+	}
+	return sb.String()
+}
+
+// Raw HTML in Markdown is dropped by the renderer; the policy below still
+// runs over its output to catch dangerous link targets.
+var markdown = goldmark.New(goldmark.WithExtensions(
+	extension.Linkify,
+	extension.Strikethrough,
+	extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+))
+
+var (
+	policy     = newPolicy()
+	textPolicy = bluemonday.StrictPolicy()
+
+	// embedSize caps image dimensions at 999 pixels so an embed cannot
+	// blow up the layout it is shown in.
+	embedSize = regexp.MustCompile(`^[0-9]{1,3}$`)
+)
+
+// newPolicy allows text formatting, lists, tables, links and images over
+// http(s). Scripts, styles, forms, iframes and other embeds are removed, as
+// are javascript: and data: URLs.
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowStandardURLs()
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+
+	p.AllowElements("p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6",
+		"strong", "b", "em", "i", "u", "s", "del", "sub", "sup",
+		"blockquote", "pre", "code", "ul", "ol", "li",
+		"table", "thead", "tbody", "tr", "th", "td")
+	p.AllowAttrs("href", "title").OnElements("a")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("align").Matching(bluemonday.CellAlign).OnElements("th", "td")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+
+	p.AllowAttrs("src", "alt", "title").OnElements("img")
+	p.AllowAttrs("width", "height").Matching(embedSize).OnElements("img")
+	return p
+}
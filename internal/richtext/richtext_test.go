@@ -0,0 +1,163 @@
+package richtext
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTMLStripsDangerousMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		body     string
+		want     []string
+		unwanted []string
+	}{
+		{
+			name:     "html script",
+			format:   FormatHTML,
+			body:     `<p>Hi</p><script>alert(1)</script>`,
+			want:     []string{"<p>Hi</p>"},
+			unwanted: []string{"<script", "alert(1)"},
+		},
+		{
+			name:     "html style and iframe",
+			format:   FormatHTML,
+			body:     `<style>p{}</style><iframe src="https://example.com"></iframe><p>Hi</p>`,
+			want:     []string{"<p>Hi</p>"},
+			unwanted: []string{"<style", "<iframe"},
+		},
+		{
+			name:     "html event handlers",
+			format:   FormatHTML,
+			body:     `<p onclick="steal()">Hi</p><img src="https://example.com/a.png" onerror="steal()">`,
+			want:     []string{"<p>Hi</p>", `<img src="https://example.com/a.png">`},
+			unwanted: []string{"onclick", "onerror", "steal"},
+		},
+		{
+			name:     "html javascript link",
+			format:   FormatHTML,
+			body:     `<a href="javascript:alert(1)">click</a> <a href="JaVaScRiPt:alert(1)">again</a>`,
+			want:     []string{"click", "again"},
+			unwanted: []string{"javascript:", "JaVaScRiPt:", "href"},
+		},
+		{
+			name:     "html data image",
+			format:   FormatHTML,
+			body:     `<img src="data:image/svg+xml;base64,PHN2Zz4=">`,
+			unwanted: []string{"data:"},
+		},
+		{
+			name:     "html oversized image",
+			format:   FormatHTML,
+			body:     `<img src="https://example.com/a.png" width="4000" height="300">`,
+			want:     []string{`height="300"`},
+			unwanted: []string{"4000"},
+		},
+		{
+			name:   "html safe link",
+			format: FormatHTML,
+			body:   `<a href="https://example.com">site</a>`,
+			want:   []string{`href="https://example.com"`, `target="_blank"`},
+		},
+		{
+			name:     "markdown javascript link",
+			format:   FormatMarkdown,
+			body:     "[click](javascript:alert(1))",
+			want:     []string{"click"},
+			unwanted: []string{"javascript:"},
+		},
+		{
+			name:     "markdown raw html",
+			format:   FormatMarkdown,
+			body:     "Hi <script>alert(1)</script> <b onmouseover=\"steal()\">there</b>",
+			want:     []string{"Hi"},
+			unwanted: []string{"<script", "onmouseover"},
+		},
+		{
+			name:     "plain text is escaped",
+			format:   FormatPlain,
+			body:     `<script>alert(1)</script> <a href="javascript:x">`,
+			want:     []string{"&lt;script&gt;"},
+			unwanted: []string{"<script", "<a "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToHTML(tt.format, tt.body)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("ToHTML = %q, want it to contain %q", got, want)
+				}
+			}
+			for _, unwanted := range tt.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("ToHTML = %q, want no %q", got, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestToHTMLFormats(t *testing.T) {
+	tests := []struct {
+		format, body, want string
+	}{
+		{FormatPlain, "Scope\nline two\n\n**not bold**", "<p>Scope<br>\nline two</p>\n<p>**not bold**</p>\n"},
+		{FormatMarkdown, "**bold** and ~~gone~~", "<p><strong>bold</strong> and <del>gone</del></p>\n"},
+		{"", "# Heading", "<h1>Heading</h1>\n"},
+		{FormatHTML, "<p><em>kept</em></p>", "<p><em>kept</em></p>"},
+		{FormatHTML, "  \n ", ""},
+	}
+	for _, tt := range tests {
+		if got := ToHTML(tt.format, tt.body); got != tt.want {
+			t.Errorf("ToHTML(%q, %q) = %q, want %q", tt.format, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name, format, body, want, wantErr string
+	}{
+		{name: "html is sanitized", format: FormatHTML, body: ` <p onclick="x()">Hi</p><script>x()</script> `, want: "<p>Hi</p>"},
+		{name: "markdown kept as written", format: FormatMarkdown, body: "<script>x()</script>", want: "<script>x()</script>"},
+		{name: "plain kept as written", format: FormatPlain, body: "a < b", want: "a < b"},
+		{name: "empty format", format: "", body: "*a*", want: "*a*"},
+		{name: "unknown format", format: "rtf", body: "x", wantErr: "unknown body format"},
+		{name: "invalid utf-8", format: FormatPlain, body: "\xff\xfe", wantErr: "not valid UTF-8"},
+		{name: "too large", format: FormatPlain, body: strings.Repeat("a", MaxBodyBytes+1), wantErr: "larger than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sanitize(tt.format, tt.body)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Sanitize error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sanitize: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Sanitize = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		format, body, want string
+	}{
+		{FormatPlain, "a < b", "a < b"},
+		{FormatMarkdown, "**bold**", "**bold**"},
+		{FormatHTML, "<p>One &amp; <b>two</b></p><p>Three<br>four</p><script>x()</script>", "One & two\nThree\nfour\n"},
+	}
+	for _, tt := range tests {
+		if got := PlainText(tt.format, tt.body); got != tt.want {
+			t.Errorf("PlainText(%q, %q) = %q, want %q", tt.format, tt.body, got, tt.want)
+		}
+	}
+}
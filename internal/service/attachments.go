@@ -0,0 +1,265 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxAttachmentsPerProposal keeps proposal documents well below MongoDB's
+// document size limit.
+const maxAttachmentsPerProposal = 20
+
+// AttachmentLimits bounds what may be uploaded as a proposal attachment.
+type AttachmentLimits struct {
+	MaxBytes     int64
+	AllowedTypes []string
+}
+
+func (l AttachmentLimits) allows(contentType string) bool {
+	for _, allowed := range l.AllowedTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// sniffedTypes are the allowed types http.DetectContentType recognizes. A
+// file declared as one of them must sniff as it too; other types cannot be
+// told apart from arbitrary bytes and are taken as declared.
+var sniffedTypes = map[string]bool{
+	"application/pdf": true,
+	"application/zip": true,
+	"image/bmp":       true,
+	"image/gif":       true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"text/html":       true,
+	"text/plain":      true,
+	"text/xml":        true,
+}
+
+// checkContentType compares a declared media type with the type sniffed from
+// the first bytes of the content. Office documents are ZIP containers and
+// sniff as such.
+func checkContentType(declared string, head []byte) error {
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	switch {
+	case sniffed == declared:
+		return nil
+	case sniffed == "application/zip" && (strings.HasPrefix(declared, "application/vnd.openxmlformats-officedocument.") || strings.HasSuffix(declared, "+zip")):
+		return nil
+	case sniffed == "application/octet-stream" && !sniffedTypes[declared]:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "attachment content looks like %s, not the declared %s", sniffed, declared)
+}
+
+var errAttachmentTooLarge = errors.New("attachment too large")
+
+// sizeLimitReader fails once more than limit bytes have been read, so an
+// oversized upload is cut off instead of stored.
+type sizeLimitReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, errAttachmentTooLarge
+	}
+	return n, err
+}
+
+// UploadAttachment stores the content read from r and attaches it to the
+// proposal as a new version.
+func (s *ProposalService) UploadAttachment(ctx context.Context, id, name, contentType string, r io.Reader, actor model.Actor) (*model.Proposal, *model.Attachment, error) {
+	if actor.Role != model.RoleFreelancer {
+		return nil, nil, status.Error(codes.PermissionDenied, "only the freelancer can attach files")
+	}
+
+	name = filepath.Base(strings.TrimSpace(name))
+	if name == "" || name == "." || name == string(filepath.Separator) || len(name) > 255 {
+		return nil, nil, status.Error(codes.InvalidArgument, "attachment needs a file name of at most 255 bytes")
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !s.attachmentLimits.allows(mediaType) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "content type %q is not allowed; allowed types are %s", contentType, strings.Join(s.attachmentLimits.AllowedTypes, ", "))
+	}
+
+	current, err := s.GetProposalByID(ctx, id, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkCanAttach(current); err != nil {
+		return nil, nil, err
+	}
+
+	// The declared type is the client's word; check it against the content
+	// before anything is stored.
+	content := bufio.NewReaderSize(r, 512)
+	head, err := content.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	if len(head) > 0 {
+		if err := checkContentType(mediaType, head); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	attachment := model.Attachment{
+		ID:          primitive.NewObjectID().Hex(),
+		Name:        name,
+		ContentType: mediaType,
+		UploadedBy:  actor.UserID,
+	}
+
+	hash := sha256.New()
+	limited := &sizeLimitReader{r: content, limit: s.attachmentLimits.MaxBytes}
+	size, err := s.blobs.Put(ctx, attachment.ID, io.TeeReader(limited, hash))
+	if err != nil {
+		if errors.Is(err, errAttachmentTooLarge) {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "attachment exceeds the %d byte limit", s.attachmentLimits.MaxBytes)
+		}
+		return nil, nil, err
+	}
+	if size == 0 {
+		s.deleteBlob(attachment.ID)
+		return nil, nil, status.Error(codes.InvalidArgument, "attachment is empty")
+	}
+	attachment.Size = size
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+	attachment.UploadedAt = time.Now()
+
+	// Another edit may land while the bytes upload; re-read and append to the
+	// latest version rather than failing the whole upload.
+	for attempt := 0; ; attempt++ {
+		attachments := append(append([]model.Attachment{}, current.Attachments...), attachment)
+		proposal, err := s.UpdateProposal(ctx, id, model.Proposal{
+			Attachments: attachments,
+			Version:     current.Version,
+		}, []string{model.FieldAttachments}, actor)
+		if err == nil {
+			return proposal, &attachment, nil
+		}
+		if status.Code(err) != codes.Aborted || attempt+1 >= maxSectionEditAttempts {
+			s.deleteBlob(attachment.ID)
+			return nil, nil, err
+		}
+
+		current, err = s.GetProposalByID(ctx, id, actor)
+		if err == nil {
+			err = checkCanAttach(current)
+		}
+		if err != nil {
+			s.deleteBlob(attachment.ID)
+			return nil, nil, err
+		}
+	}
+}
+
+func checkCanAttach(p *model.Proposal) error {
+	if !isEditableStatus(p.Status) {
+		return status.Errorf(codes.FailedPrecondition, "cannot edit a proposal in status %s", p.Status)
+	}
+	if len(p.Attachments) >= maxAttachmentsPerProposal {
+		return status.Errorf(codes.FailedPrecondition, "a proposal can have at most %d attachments", maxAttachmentsPerProposal)
+	}
+	return nil
+}
+
+// deleteBlob removes the bytes of an upload that did not make it onto a
+// proposal or profile, or that was replaced. It outlives the request so a cancelled upload is still cleaned up.
+func (s *ProposalService) deleteBlob(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.blobs.Delete(ctx, id); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Failed to delete orphaned blob %s: %v", id, err)
+	}
+}
+
+// RemoveAttachment detaches an attachment from the proposal as a new version
+// and deletes its content. Without an expected version it applies to the
+// latest version.
+func (s *ProposalService) RemoveAttachment(ctx context.Context, id, attachmentID string, expectedVersion int, actor model.Actor) (*model.Proposal, error) {
+	if actor.Role != model.RoleFreelancer {
+		return nil, status.Error(codes.PermissionDenied, "only the freelancer can remove attachments")
+	}
+
+	current, err := s.GetProposalByID(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !isEditableStatus(current.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot edit a proposal in status %s", current.Status)
+	}
+
+	attachments := make([]model.Attachment, 0, len(current.Attachments))
+	for _, attachment := range current.Attachments {
+		if attachment.ID != attachmentID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	if len(attachments) == len(current.Attachments) {
+		return nil, status.Errorf(codes.NotFound, "attachment %s not found", attachmentID)
+	}
+	if expectedVersion == 0 {
+		expectedVersion = current.Version
+	}
+
+	proposal, err := s.UpdateProposal(ctx, id, model.Proposal{
+		Attachments: attachments,
+		Version:     expectedVersion,
+	}, []string{model.FieldAttachments}, actor)
+	if err != nil {
+		return nil, err
+	}
+	s.deleteBlob(attachmentID)
+	return proposal, nil
+}
+
+// DownloadAttachment opens an attachment of a proposal the caller is a party
+// to. The caller closes the returned reader.
+func (s *ProposalService) DownloadAttachment(ctx context.Context, id, attachmentID string, actor model.Actor) (*model.Attachment, io.ReadCloser, error) {
+	proposal, err := s.GetProposalByID(ctx, id, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range proposal.Attachments {
+		attachment := &proposal.Attachments[i]
+		if attachment.ID != attachmentID {
+			continue
+		}
+		content, err := s.blobs.Open(ctx, attachment.ID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, nil, status.Errorf(codes.NotFound, "content of attachment %s is missing", attachmentID)
+			}
+			return nil, nil, err
+		}
+		return attachment, content, nil
+	}
+	return nil, nil, status.Errorf(codes.NotFound, "attachment %s not found", attachmentID)
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/storage"
+	"google.golang.org/grpc/codes"
+)
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// newAttachmentService returns a service storing blobs in a fresh directory,
+// with one draft proposal owned by freelancer.
+func newAttachmentService(t *testing.T, maxBytes int64) (*ProposalService, *memRepository, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	blobs, err := storage.NewLocalStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := newMemRepository()
+	id := repo.add(model.Proposal{ClientID: "client-1", FreelancerID: freelancer.UserID, Title: "Logo design", Status: model.StatusDraft})
+	limits := AttachmentLimits{MaxBytes: maxBytes, AllowedTypes: []string{"application/pdf", "image/png", "text/plain"}}
+	return NewProposalService(repo, blobs, limits, 50), repo, id, dir
+}
+
+// blobCount counts what is left in the blob directory, including temporary
+// files of unfinished uploads.
+func blobCount(t *testing.T, dir string) int {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(entries)
+}
+
+func TestUploadAttachmentStoresContentAndChecksum(t *testing.T) {
+	s, repo, id, dir := newAttachmentService(t, 1<<20)
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte("x"), 2000)...)
+
+	proposal, attachment, err := s.UploadAttachment(context.Background(), id, "../mockup.png", "image/png", bytes.NewReader(content), freelancer)
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+
+	sum := sha256.Sum256(content)
+	if attachment.Checksum != hex.EncodeToString(sum[:]) {
+		t.Errorf("checksum = %s, want %s", attachment.Checksum, hex.EncodeToString(sum[:]))
+	}
+	if attachment.Size != int64(len(content)) || attachment.Name != "mockup.png" || attachment.ContentType != "image/png" {
+		t.Errorf("attachment = %+v", attachment)
+	}
+	if len(proposal.Attachments) != 1 || proposal.Attachments[0] != *attachment || proposal.Version != 2 {
+		t.Errorf("proposal version %d has attachments %+v", proposal.Version, proposal.Attachments)
+	}
+	if stored := repo.get(id); len(stored.Attachments) != 1 {
+		t.Errorf("stored proposal has %d attachments, want 1", len(stored.Attachments))
+	}
+	if n := blobCount(t, dir); n != 1 {
+		t.Errorf("blob directory holds %d files, want 1", n)
+	}
+
+	_, r, err := s.DownloadAttachment(context.Background(), id, attachment.ID, freelancer)
+	if err != nil {
+		t.Fatalf("DownloadAttachment: %v", err)
+	}
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("downloaded %d bytes that differ from the upload", len(got))
+	}
+}
+
+func TestUploadAttachmentEnforcesSizeLimit(t *testing.T) {
+	s, repo, id, dir := newAttachmentService(t, 100)
+
+	_, _, err := s.UploadAttachment(context.Background(), id, "notes.txt", "text/plain", strings.NewReader(strings.Repeat("a", 101)), freelancer)
+	wantCode(t, err, codes.InvalidArgument)
+
+	if n := blobCount(t, dir); n != 0 {
+		t.Errorf("oversized upload left %d files behind", n)
+	}
+	if stored := repo.get(id); len(stored.Attachments) != 0 || stored.Version != 1 {
+		t.Errorf("oversized upload changed the proposal: %+v", stored.Attachments)
+	}
+
+	if _, _, err := s.UploadAttachment(context.Background(), id, "notes.txt", "text/plain", strings.NewReader(strings.Repeat("a", 100)), freelancer); err != nil {
+		t.Errorf("upload at the limit: %v", err)
+	}
+}
+
+func TestUploadAttachmentRejectsContentTypes(t *testing.T) {
+	s, _, id, dir := newAttachmentService(t, 1<<20)
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     []byte
+	}{
+		{"type not allowed", "application/x-msdownload", []byte("MZ\x90\x00")},
+		{"malformed type", "image/", pngHeader},
+		{"content is not the declared type", "image/png", []byte("just some text")},
+		{"html declared as text", "text/plain", []byte("<html><script>alert(1)</script></html>")},
+		{"pdf declared as image", "image/png", []byte("%PDF-1.7\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.UploadAttachment(context.Background(), id, "file", tt.contentType, bytes.NewReader(tt.content), freelancer)
+			wantCode(t, err, codes.InvalidArgument)
+		})
+	}
+	if n := blobCount(t, dir); n != 0 {
+		t.Errorf("rejected uploads left %d files behind", n)
+	}
+}
+
+func TestUploadAttachmentDeletesBlobWhenUpdateFails(t *testing.T) {
+	s, repo, id, dir := newAttachmentService(t, 1<<20)
+	repo.updateErr = errors.New("database unavailable")
+
+	_, _, err := s.UploadAttachment(context.Background(), id, "brief.pdf", "application/pdf", strings.NewReader("%PDF-1.7\n..."), freelancer)
+	if err == nil || !strings.Contains(err.Error(), "database unavailable") {
+		t.Fatalf("error = %v, want the update failure", err)
+	}
+	if n := blobCount(t, dir); n != 0 {
+		t.Errorf("failed upload left %d files behind", n)
+	}
+}
+
+func TestRemoveAttachmentDeletesContent(t *testing.T) {
+	s, repo, id, dir := newAttachmentService(t, 1<<20)
+	_, kept, err := s.UploadAttachment(context.Background(), id, "brief.pdf", "application/pdf", strings.NewReader("%PDF-1.7\n..."), freelancer)
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	_, removed, err := s.UploadAttachment(context.Background(), id, "notes.txt", "text/plain", strings.NewReader("call on Monday"), freelancer)
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+
+	_, err = s.RemoveAttachment(context.Background(), id, removed.ID, 0, model.Actor{UserID: "client-1", Role: model.RoleClient})
+	wantCode(t, err, codes.PermissionDenied)
+	_, err = s.RemoveAttachment(context.Background(), id, "unknown", 0, freelancer)
+	wantCode(t, err, codes.NotFound)
+	_, err = s.RemoveAttachment(context.Background(), id, removed.ID, 2, freelancer)
+	wantCode(t, err, codes.Aborted)
+
+	proposal, err := s.RemoveAttachment(context.Background(), id, removed.ID, 3, freelancer)
+	if err != nil {
+		t.Fatalf("RemoveAttachment: %v", err)
+	}
+	if len(proposal.Attachments) != 1 || proposal.Attachments[0].ID != kept.ID || proposal.Version != 4 {
+		t.Errorf("proposal version %d has attachments %+v, want only %s", proposal.Version, proposal.Attachments, kept.ID)
+	}
+	if n := blobCount(t, dir); n != 1 {
+		t.Errorf("blob directory holds %d files, want only the kept attachment", n)
+	}
+	_, _, err = s.DownloadAttachment(context.Background(), id, removed.ID, freelancer)
+	wantCode(t, err, codes.NotFound)
+
+	// Version 3 still lists the removed attachment; restoring it must not
+	// point the proposal at the deleted content.
+	restored, err := s.RestoreRevision(context.Background(), id, 3, 4, freelancer)
+	if err != nil {
+		t.Fatalf("RestoreRevision: %v", err)
+	}
+	if len(restored.Attachments) != 1 || restored.Attachments[0].ID != kept.ID {
+		t.Errorf("restored attachments = %+v, want the current ones", restored.Attachments)
+	}
+	if stored := repo.get(id); len(stored.Attachments) != 1 {
+		t.Errorf("stored proposal has %d attachments, want 1", len(stored.Attachments))
+	}
+}
+
+func TestCheckContentType(t *testing.T) {
+	tests := []struct {
+		declared string
+		head     []byte
+		ok       bool
+	}{
+		{"image/png", pngHeader, true},
+		{"text/plain", []byte("hello"), true},
+		{"application/pdf", []byte("%PDF-1.4"), true},
+		{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", []byte("PK\x03\x04"), true},
+		{"application/msword", []byte{0xD0, 0xCF, 0x11, 0xE0}, true},
+		{"image/jpeg", pngHeader, false},
+		{"application/pdf", []byte{0x00, 0x01, 0x02}, false},
+		{"application/zip", []byte("%PDF-1.4"), false},
+	}
+	for _, tt := range tests {
+		err := checkContentType(tt.declared, tt.head)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkContentType(%s, %q) = %v, want ok %v", tt.declared, tt.head, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// prepareSections validates and sanitizes section bodies in place and gives
+// bodies without a format the default one.
+func prepareSections(sections []model.Section) error {
+	for i := range sections {
+		sec := &sections[i]
+		if sec.Format == "" {
+			sec.Format = richtext.DefaultFormat
+		}
+		body, err := richtext.Sanitize(sec.Format, sec.Body)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "section %q: %v", sec.Heading, err)
+		}
+		sec.Body = body
+	}
+	return nil
+}
+
+// prepareContent does the same for a proposal's free-text content.
+func prepareContent(p *model.Proposal) error {
+	if p.ContentFormat == "" {
+		p.ContentFormat = richtext.DefaultFormat
+	}
+	content, err := richtext.Sanitize(p.ContentFormat, p.Content)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "content: %v", err)
+	}
+	p.Content = content
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Event types published for every proposal lifecycle change. Status changes
+// get one type per target status so consumers can subscribe to just the
+// transitions they care about.
+const (
+	EventProposalCreated            = "proposal.created"
+	EventProposalSent               = "proposal.sent"
+	EventProposalAccepted           = "proposal.accepted"
+	EventProposalRejected           = "proposal.rejected"
+	EventProposalWithdrawn          = "proposal.withdrawn"
+	EventProposalExpired            = "proposal.expired"
+	EventProposalContracted         = "proposal.contracted"
+	EventProposalArchived           = "proposal.archived"
+	EventProposalContentUpdated     = "proposal.content.updated"
+	EventProposalDeadlineUpdated    = "proposal.deadline.updated"
+	EventProposalPricingUpdated     = "proposal.pricing.updated"
+	EventProposalMilestonesUpdated  = "proposal.milestones.updated"
+	EventProposalAttachmentsUpdated = "proposal.attachments.updated"
+)
+
+var statusEventTypes = map[string]string{
+	model.StatusSent:       EventProposalSent,
+	model.StatusAccepted:   EventProposalAccepted,
+	model.StatusRejected:   EventProposalRejected,
+	model.StatusWithdrawn:  EventProposalWithdrawn,
+	model.StatusExpired:    EventProposalExpired,
+	model.StatusContracted: EventProposalContracted,
+}
+
+// changeEvents lists the events describing how update changes current.
+func changeEvents(current *model.Proposal, update model.Proposal, actor model.Actor) []model.OutboxEvent {
+	var events []model.OutboxEvent
+	add := func(eventType string) {
+		events = append(events, model.OutboxEvent{EventType: eventType, Actor: actor})
+	}
+
+	if update.Title != current.Title || update.Content != current.Content ||
+		(update.Sections != nil && !reflect.DeepEqual(update.Sections, current.Sections)) {
+		add(EventProposalContentUpdated)
+	}
+	if !update.Deadline.IsZero() && !update.Deadline.Equal(current.Deadline) {
+		add(EventProposalDeadlineUpdated)
+	}
+	if !reflect.DeepEqual(update.Pricing, current.Pricing) {
+		add(EventProposalPricingUpdated)
+	}
+	if !reflect.DeepEqual(update.Milestones, current.Milestones) {
+		add(EventProposalMilestonesUpdated)
+	}
+	if !reflect.DeepEqual(update.Attachments, current.Attachments) {
+		add(EventProposalAttachmentsUpdated)
+	}
+	if update.Status != current.Status {
+		if eventType, ok := statusEventTypes[update.Status]; ok {
+			add(eventType)
+		}
+	}
+	return events
+}
+
+// OnEventDelivered runs once an outbox event has reached Kafka. A newly
+// created proposal counts as sent only after its creation event is out, so
+// a lost publish can no longer leave a proposal marked sent.
+func (s *ProposalService) OnEventDelivered(ctx context.Context, event *model.OutboxEvent) error {
+	if event.EventType != EventProposalCreated {
+		return nil
+	}
+
+	current, err := s.repo.GetProposalByID(ctx, event.ProposalID.Hex())
+	if err != nil {
+		// The proposal was archived before its creation went out.
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return err
+	}
+	// Anything past draft has been moved on by someone else; leave it be.
+	if current.Status != model.StatusDraft {
+		return nil
+	}
+
+	_, err = s.UpdateProposal(ctx, current.ID.Hex(), model.Proposal{
+		Status:  model.StatusSent,
+		Version: current.Version,
+	}, []string{model.FieldStatus}, model.Actor{Role: model.RoleSystem})
+	return err
+}
+
+// ExpireProposals moves draft and sent proposals past their deadline to
+// expired, one proposal at a time so each gets its own expiry event. A
+// proposal changed concurrently is skipped and picked up on the next run.
+func (s *ProposalService) ExpireProposals(ctx context.Context) error {
+	due, err := s.repo.GetProposalsDueForExpiry(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, p := range due {
+		if err := s.setStatus(ctx, p, model.StatusExpired, ""); err != nil {
+			log.Printf("failed to expire proposal %s: %v", p.ID.Hex(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/export"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxLogoBytes keeps logos small enough to inline into every export.
+const maxLogoBytes = 512 * 1024
+
+const maxFooterLength = 500
+
+// BrandingUpdate is a freelancer's new branding. The stored logo is kept
+// unless Logo is set or RemoveLogo is true.
+type BrandingUpdate struct {
+	AccentColor string
+	Footer      string
+	Logo        []byte
+	RemoveLogo  bool
+}
+
+// GetBrandingProfile returns the caller's branding profile, which is empty
+// until they save one.
+func (s *ProposalService) GetBrandingProfile(ctx context.Context, actor model.Actor) (*model.BrandingProfile, error) {
+	if actor.Role != model.RoleFreelancer {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers have a branding profile")
+	}
+	profile, err := s.repo.GetBrandingProfile(ctx, actor.UserID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &model.BrandingProfile{FreelancerID: actor.UserID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// SetBrandingProfile replaces the caller's branding profile.
+func (s *ProposalService) SetBrandingProfile(ctx context.Context, update BrandingUpdate, actor model.Actor) (*model.BrandingProfile, error) {
+	if actor.Role != model.RoleFreelancer {
+		return nil, status.Error(codes.PermissionDenied, "only freelancers have a branding profile")
+	}
+
+	accent := strings.ToUpper(strings.TrimSpace(update.AccentColor))
+	if accent != "" && !export.ValidAccentColor(accent) {
+		return nil, status.Errorf(codes.InvalidArgument, "accent color must look like #1F4E79, got %q", update.AccentColor)
+	}
+	footer := strings.TrimSpace(update.Footer)
+	if utf8.RuneCountInString(footer) > maxFooterLength {
+		return nil, status.Errorf(codes.InvalidArgument, "footer is longer than %d characters", maxFooterLength)
+	}
+	if len(update.Logo) > 0 && update.RemoveLogo {
+		return nil, status.Error(codes.InvalidArgument, "cannot both set and remove the logo")
+	}
+
+	current, err := s.GetBrandingProfile(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	profile := model.BrandingProfile{
+		FreelancerID:    actor.UserID,
+		AccentColor:     accent,
+		Footer:          footer,
+		LogoBlobID:      current.LogoBlobID,
+		LogoContentType: current.LogoContentType,
+	}
+	if update.RemoveLogo {
+		profile.LogoBlobID, profile.LogoContentType = "", ""
+	}
+
+	var newLogo string
+	if len(update.Logo) > 0 {
+		contentType, err := checkLogo(update.Logo)
+		if err != nil {
+			return nil, err
+		}
+		newLogo = "logo-" + primitive.NewObjectID().Hex()
+		if _, err := s.blobs.Put(ctx, newLogo, bytes.NewReader(update.Logo)); err != nil {
+			return nil, err
+		}
+		profile.LogoBlobID, profile.LogoContentType = newLogo, contentType
+	}
+
+	saved, previous, err := s.repo.SaveBrandingProfile(ctx, profile)
+	if err != nil {
+		if newLogo != "" {
+			s.deleteBlob(newLogo)
+		}
+		return nil, err
+	}
+	if previous != nil && previous.LogoBlobID != "" && previous.LogoBlobID != saved.LogoBlobID {
+		s.deleteBlob(previous.LogoBlobID)
+	}
+	return saved, nil
+}
+
+// checkLogo makes sure a logo is a PNG or JPEG image that every export
+// format can show, and returns its content type.
+func checkLogo(logo []byte) (string, error) {
+	if len(logo) > maxLogoBytes {
+		return "", status.Errorf(codes.InvalidArgument, "logo exceeds the %d byte limit", maxLogoBytes)
+	}
+	_, format, err := image.DecodeConfig(bytes.NewReader(logo))
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "logo must be a PNG or JPEG image")
+	}
+	return "image/" + format, nil
+}
+
+// ExportedProposal is a proposal ready to be rendered as a downloadable
+// document.
+type ExportedProposal struct {
+	FileName    string
+	ContentType string
+
+	format   string
+	proposal *model.Proposal
+	branding export.Branding
+}
+
+// Render writes the document to w as it is rendered, so large exports are
+// never held in memory whole.
+func (e *ExportedProposal) Render(w io.Writer) error {
+	if err := export.Render(w, e.format, e.proposal, e.branding); err != nil {
+		return status.Errorf(codes.Internal, "failed to render proposal: %v", err)
+	}
+	return nil
+}
+
+// ExportProposal prepares a proposal the caller is a party to for export in
+// the given format, branded with its freelancer's profile.
+func (s *ProposalService) ExportProposal(ctx context.Context, id, format string, actor model.Actor) (*ExportedProposal, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	contentType := export.ContentType(format)
+	if contentType == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "export format must be one of %s", strings.Join(export.Formats(), ", "))
+	}
+
+	proposal, err := s.GetProposalByID(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	branding, err := s.exportBranding(ctx, proposal.FreelancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportedProposal{
+		FileName:    export.FileName(proposal, format),
+		ContentType: contentType,
+		format:      format,
+		proposal:    proposal,
+		branding:    branding,
+	}, nil
+}
+
+// exportBranding loads a freelancer's branding for an export. A logo that
+// cannot be read is left out rather than failing the export.
+func (s *ProposalService) exportBranding(ctx context.Context, freelancerID string) (export.Branding, error) {
+	profile, err := s.repo.GetBrandingProfile(ctx, freelancerID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return export.Branding{}, nil
+	}
+	if err != nil {
+		return export.Branding{}, err
+	}
+
+	branding := export.Branding{AccentColor: profile.AccentColor, Footer: profile.Footer}
+	if profile.LogoBlobID != "" {
+		logo, err := s.readLogo(ctx, profile.LogoBlobID)
+		if err != nil {
+			log.Printf("Failed to load logo %s of freelancer %s: %v", profile.LogoBlobID, freelancerID, err)
+		} else {
+			branding.Logo, branding.LogoType = logo, profile.LogoContentType
+		}
+	}
+	return branding, nil
+}
+
+func (s *ProposalService) readLogo(ctx context.Context, blobID string) ([]byte, error) {
+	content, err := s.blobs.Open(ctx, blobID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	logo, err := io.ReadAll(io.LimitReader(content, maxLogoBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(logo) > maxLogoBytes {
+		return nil, fmt.Errorf("logo is larger than %d bytes", maxLogoBytes)
+	}
+	return logo, nil
+}
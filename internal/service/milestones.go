@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetMilestones replaces the payment schedule of a proposal.
+func (s *ProposalService) SetMilestones(ctx context.Context, id string, milestones []model.Milestone, expectedVersion int, actor model.Actor) (*model.Proposal, error) {
+	if actor.Role != model.RoleFreelancer {
+		return nil, status.Error(codes.PermissionDenied, "only the freelancer can set milestones")
+	}
+	if milestones == nil {
+		milestones = []model.Milestone{}
+	}
+	// Without an expected version the schedule replaces whatever is current.
+	if expectedVersion == 0 {
+		current, err := s.GetProposalByID(ctx, id, actor)
+		if err != nil {
+			return nil, err
+		}
+		expectedVersion = current.Version
+	}
+	return s.UpdateProposal(ctx, id, model.Proposal{
+		Milestones: milestones,
+		Version:    expectedVersion,
+	}, []string{model.FieldMilestones}, actor)
+}
+
+// checkMilestones validates a payment schedule against the pricing and
+// deadline it will be stored with, and gives new milestones an id.
+func checkMilestones(milestones []model.Milestone, pricing *model.Pricing, deadline time.Time) error {
+	if len(milestones) == 0 {
+		return nil
+	}
+	if pricing == nil {
+		return status.Error(codes.FailedPrecondition, "set the proposal pricing before adding milestones")
+	}
+
+	var sum int64
+	for i := range milestones {
+		m := &milestones[i]
+		m.Title = strings.TrimSpace(m.Title)
+		if m.Title == "" {
+			return status.Errorf(codes.InvalidArgument, "milestone %d needs a title", i+1)
+		}
+		if m.AmountCents <= 0 || m.AmountCents > maxPricingCents {
+			return status.Errorf(codes.InvalidArgument, "milestone %q needs a positive amount", m.Title)
+		}
+		if m.DueDate.IsZero() {
+			return status.Errorf(codes.InvalidArgument, "milestone %q needs a due date", m.Title)
+		}
+		if !deadline.IsZero() && m.DueDate.After(deadline) {
+			return status.Errorf(codes.FailedPrecondition, "milestone %q is due after the proposal deadline", m.Title)
+		}
+		if m.ID == "" {
+			m.ID = primitive.NewObjectID().Hex()
+		}
+		sum += m.AmountCents
+	}
+
+	if sum != pricing.TotalCents {
+		return status.Errorf(codes.FailedPrecondition, "milestone amounts sum to %d but the proposal total is %d", sum, pricing.TotalCents)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCreateProposalWithMilestonesAndNoDeadline(t *testing.T) {
+	s := NewProposalService(newMemRepository(), nil, AttachmentLimits{}, 50)
+	proposal := model.Proposal{
+		ClientID:     "client-1",
+		FreelancerID: freelancer.UserID,
+		Title:        "Logo design",
+		Status:       model.StatusDraft,
+		Pricing:      &model.Pricing{Currency: "usd", Type: model.PricingFixed, FixedPriceCents: 100_000},
+		Milestones: []model.Milestone{
+			{Title: "Concepts", DueDate: time.Now().Add(7 * 24 * time.Hour), AmountCents: 40_000},
+			{Title: "Final files", DueDate: time.Now().Add(21 * 24 * time.Hour), AmountCents: 60_000},
+		},
+	}
+
+	created, err := s.CreateProposal(context.Background(), proposal, freelancer)
+	if err != nil {
+		t.Fatalf("CreateProposal: %v", err)
+	}
+	if !created.Deadline.IsZero() || len(created.Milestones) != 2 {
+		t.Errorf("created deadline %s with %d milestones", created.Deadline, len(created.Milestones))
+	}
+
+	proposal.Deadline = time.Now().Add(14 * 24 * time.Hour)
+	_, err = s.CreateProposal(context.Background(), proposal, freelancer)
+	wantCode(t, err, codes.FailedPrecondition)
+}
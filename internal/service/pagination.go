@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultListPageSize = 50
+
+var listSortFields = map[string]bool{
+	model.SortCreatedAt: true,
+	model.SortUpdatedAt: true,
+	model.SortDeadline:  true,
+	model.SortStatus:    true,
+}
+
+// ListOptions select a page of ListProposals. PageToken continues a listing
+// and must be used with the sort and filters it was issued for.
+type ListOptions struct {
+	PageSize   int64
+	PageToken  string
+	SortBy     string // defaults to updated_at
+	Descending bool
+	Skip       int64 // deprecated; ignored with a PageToken
+}
+
+// ProposalList is one page of proposals. NextPageToken is empty on the last
+// page. TotalCount is counted again for every page, which costs a
+// CountDocuments over the whole filter on top of the page query.
+type ProposalList struct {
+	Proposals     []*model.Proposal
+	NextPageToken string
+	TotalCount    int64
+}
+
+// pageToken is the decoded form of an opaque page token.
+type pageToken struct {
+	SortBy     string     `json:"s"`
+	Descending bool       `json:"d,omitempty"`
+	Time       *time.Time `json:"t,omitempty"`
+	Status     string     `json:"v,omitempty"`
+	ID         string     `json:"i"`
+	Filter     string     `json:"f"`
+}
+
+// filterHash fingerprints a normalized filter so a page token cannot continue
+// a listing under different filters.
+func filterHash(f model.ProposalFilter) string {
+	f.Statuses = append([]string(nil), f.Statuses...)
+	sort.Strings(f.Statuses)
+	data, _ := json.Marshal(f)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func encodePageToken(sortBy string, descending bool, filter string, cursor model.ProposalCursor) string {
+	token := pageToken{SortBy: sortBy, Descending: descending, ID: cursor.ID.Hex(), Filter: filter}
+	if sortBy == model.SortStatus {
+		token.Status = cursor.Status
+	} else {
+		token.Time = &cursor.Time
+	}
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(raw, sortBy string, descending bool, filter string) (*model.ProposalCursor, error) {
+	invalid := status.Error(codes.InvalidArgument, "invalid page_token")
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, invalid
+	}
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, invalid
+	}
+	id, err := primitive.ObjectIDFromHex(token.ID)
+	if err != nil {
+		return nil, invalid
+	}
+	if token.SortBy != sortBy || token.Descending != descending {
+		return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different sort order")
+	}
+	if token.Filter != filter {
+		return nil, status.Error(codes.InvalidArgument, "page_token was issued for different filters")
+	}
+
+	cursor := &model.ProposalCursor{ID: id, Status: token.Status}
+	if sortBy != model.SortStatus {
+		if token.Time == nil {
+			return nil, invalid
+		}
+		cursor.Time = *token.Time
+	}
+	return cursor, nil
+}
+
+// GetProposals lists the proposals matching filter one page at a time.
+func (s *ProposalService) GetProposals(ctx context.Context, filter model.ProposalFilter, opts ListOptions) (*ProposalList, error) {
+	if err := checkProposalFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	page := model.ProposalPage{
+		SortBy:     opts.SortBy,
+		Descending: opts.Descending,
+		Limit:      opts.PageSize,
+		Skip:       opts.Skip,
+	}
+	if page.SortBy == "" {
+		page.SortBy = model.SortUpdatedAt
+	}
+	if !listSortFields[page.SortBy] {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot sort proposals by %q", opts.SortBy)
+	}
+	if page.Limit < 0 || page.Skip < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size and skip cannot be negative")
+	}
+	if page.Limit == 0 {
+		page.Limit = min(defaultListPageSize, s.maxListPageSize)
+	}
+	page.Limit = min(page.Limit, s.maxListPageSize)
+
+	filterKey := filterHash(filter)
+	if opts.PageToken != "" {
+		cursor, err := decodePageToken(opts.PageToken, page.SortBy, page.Descending, filterKey)
+		if err != nil {
+			return nil, err
+		}
+		page.After = cursor
+	}
+
+	// Ask for one more than a page to learn whether another page follows.
+	want := page.Limit
+	page.Limit++
+	proposals, total, err := s.repo.GetProposals(ctx, filter, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve proposals: %w", err)
+	}
+
+	list := &ProposalList{Proposals: proposals, TotalCount: total}
+	if int64(len(proposals)) > want {
+		list.Proposals = proposals[:want]
+		last := list.Proposals[want-1]
+		list.NextPageToken = encodePageToken(page.SortBy, page.Descending, filterKey, model.CursorOf(last, page.SortBy))
+	}
+	return list, nil
+}
+
+// checkProposalFilter validates a listing filter and normalizes its ids,
+// statuses and currency.
+func checkProposalFilter(f *model.ProposalFilter) error {
+	f.ClientID = strings.TrimSpace(f.ClientID)
+	f.FreelancerID = strings.TrimSpace(f.FreelancerID)
+
+	statuses := make([]string, 0, len(f.Statuses))
+	seen := make(map[string]bool, len(f.Statuses))
+	for _, st := range f.Statuses {
+		st = strings.TrimSpace(st)
+		if !knownStatuses[st] {
+			return status.Errorf(codes.InvalidArgument, "invalid status filter: %q", st)
+		}
+		if !seen[st] {
+			seen[st] = true
+			statuses = append(statuses, st)
+		}
+	}
+	f.Statuses = statuses
+
+	ranges := []struct {
+		name          string
+		after, before time.Time
+	}{
+		{"created", f.CreatedAfter, f.CreatedBefore},
+		{"updated", f.UpdatedAfter, f.UpdatedBefore},
+		{"deadline", f.DeadlineAfter, f.DeadlineBefore},
+	}
+	for _, r := range ranges {
+		if !r.after.IsZero() && !r.before.IsZero() && !r.after.Before(r.before) {
+			return status.Errorf(codes.InvalidArgument, "%s_after must be before %s_before", r.name, r.name)
+		}
+	}
+
+	if f.Currency != "" {
+		f.Currency = strings.ToUpper(strings.TrimSpace(f.Currency))
+		if !currencyCode.MatchString(f.Currency) {
+			return status.Errorf(codes.InvalidArgument, "currency must be a 3-letter ISO 4217 code, got %q", f.Currency)
+		}
+	}
+	if f.MinTotalCents != nil || f.MaxTotalCents != nil {
+		if f.Currency == "" {
+			return status.Error(codes.InvalidArgument, "a price range needs a currency")
+		}
+		if (f.MinTotalCents != nil && *f.MinTotalCents < 0) || (f.MaxTotalCents != nil && *f.MaxTotalCents < 0) {
+			return status.Error(codes.InvalidArgument, "price range bounds cannot be negative")
+		}
+		if f.MinTotalCents != nil && f.MaxTotalCents != nil && *f.MinTotalCents > *f.MaxTotalCents {
+			return status.Error(codes.InvalidArgument, "min_total_cents cannot exceed max_total_cents")
+		}
+	}
+	return nil
+}
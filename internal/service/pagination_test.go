@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	filter := filterHash(model.ProposalFilter{ClientID: "client-1"})
+	cursor := model.ProposalCursor{ID: primitive.NewObjectID(), Time: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	token := encodePageToken(model.SortCreatedAt, true, filter, cursor)
+
+	got, err := decodePageToken(token, model.SortCreatedAt, true, filter)
+	if err != nil {
+		t.Fatalf("decodePageToken: %v", err)
+	}
+	if got.ID != cursor.ID || !got.Time.Equal(cursor.Time) {
+		t.Errorf("cursor = %+v, want %+v", got, cursor)
+	}
+
+	_, err = decodePageToken(token, model.SortCreatedAt, false, filter)
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = decodePageToken(token, model.SortCreatedAt, true, filterHash(model.ProposalFilter{ClientID: "client-2"}))
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = decodePageToken("not-a-token", model.SortCreatedAt, true, filter)
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestFilterHash(t *testing.T) {
+	minTotal, otherMin := int64(100), int64(200)
+	base := model.ProposalFilter{Statuses: []string{model.StatusSent, model.StatusDraft}, Currency: "USD", MinTotalCents: &minTotal}
+
+	reordered := base
+	reordered.Statuses = []string{model.StatusDraft, model.StatusSent}
+	if filterHash(base) != filterHash(reordered) {
+		t.Error("status order changed the filter hash")
+	}
+	if base.Statuses[0] != model.StatusSent {
+		t.Error("filterHash reordered the caller's statuses")
+	}
+
+	changes := map[string]func(f *model.ProposalFilter){
+		"client":    func(f *model.ProposalFilter) { f.ClientID = "client-1" },
+		"statuses":  func(f *model.ProposalFilter) { f.Statuses = []string{model.StatusDraft} },
+		"created":   func(f *model.ProposalFilter) { f.CreatedAfter = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) },
+		"min total": func(f *model.ProposalFilter) { f.MinTotalCents = &otherMin },
+		"archived":  func(f *model.ProposalFilter) { f.IncludeArchived = true },
+	}
+	for name, change := range changes {
+		changed := base
+		change(&changed)
+		if filterHash(changed) == filterHash(base) {
+			t.Errorf("changing the %s filter kept the hash", name)
+		}
+	}
+}
+
+func TestGetProposalsRejectsTokenForOtherFilters(t *testing.T) {
+	s := NewProposalService(newMemRepository(), nil, AttachmentLimits{}, 50)
+	issued := model.ProposalFilter{Statuses: []string{model.StatusSent}}
+	if err := checkProposalFilter(&issued); err != nil {
+		t.Fatal(err)
+	}
+	token := encodePageToken(model.SortUpdatedAt, true, filterHash(issued), model.ProposalCursor{ID: primitive.NewObjectID(), Time: time.Now()})
+
+	_, err := s.GetProposals(context.Background(), model.ProposalFilter{Statuses: []string{model.StatusDraft}}, ListOptions{PageToken: token, Descending: true})
+	wantCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// maxPricingCents keeps computed totals far away from int64 overflow.
+const maxPricingCents = 100_000_000_000
+
+// checkPricing validates a pricing block and computes its totals.
+func checkPricing(p *model.Pricing) error {
+	p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+	if !currencyCode.MatchString(p.Currency) {
+		return status.Errorf(codes.InvalidArgument, "pricing currency must be a 3-letter ISO 4217 code, got %q", p.Currency)
+	}
+
+	switch p.Type {
+	case model.PricingFixed:
+		if p.FixedPriceCents <= 0 {
+			return status.Error(codes.InvalidArgument, "a fixed price must be positive")
+		}
+		if p.HourlyRateCents != 0 || p.EstimatedHours != 0 {
+			return status.Error(codes.InvalidArgument, "a fixed price cannot carry an hourly rate or estimated hours")
+		}
+	case model.PricingHourly:
+		if p.HourlyRateCents <= 0 || p.EstimatedHours <= 0 {
+			return status.Error(codes.InvalidArgument, "hourly pricing needs a positive rate and estimated hours")
+		}
+		if p.FixedPriceCents != 0 {
+			return status.Error(codes.InvalidArgument, "hourly pricing cannot carry a fixed price")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "pricing type must be %s or %s", model.PricingFixed, model.PricingHourly)
+	}
+	if p.FixedPriceCents > maxPricingCents || p.HourlyRateCents > maxPricingCents || p.EstimatedHours > 100_000 {
+		return status.Error(codes.InvalidArgument, "pricing amounts are too large")
+	}
+
+	for i, item := range p.LineItems {
+		if strings.TrimSpace(item.Description) == "" {
+			return status.Errorf(codes.InvalidArgument, "line item %d needs a description", i+1)
+		}
+		if item.Quantity <= 0 || item.UnitPriceCents < 0 {
+			return status.Errorf(codes.InvalidArgument, "line item %q needs a positive quantity and a non-negative unit price", item.Description)
+		}
+		if item.Quantity > 1_000_000 || item.UnitPriceCents > maxPricingCents {
+			return status.Errorf(codes.InvalidArgument, "line item %q is too large", item.Description)
+		}
+	}
+	if err := checkAdjustments("discount", p.Discounts); err != nil {
+		return err
+	}
+	if err := checkAdjustments("tax", p.Taxes); err != nil {
+		return err
+	}
+
+	p.ComputeTotals()
+	if p.DiscountCents > p.SubtotalCents {
+		return status.Error(codes.InvalidArgument, "discounts exceed the subtotal")
+	}
+	if p.TotalCents <= 0 {
+		return status.Error(codes.InvalidArgument, "the proposal total must be positive")
+	}
+	return nil
+}
+
+func checkAdjustments(kind string, adjustments []model.Adjustment) error {
+	for i, adj := range adjustments {
+		if strings.TrimSpace(adj.Name) == "" {
+			return status.Errorf(codes.InvalidArgument, "%s %d needs a name", kind, i+1)
+		}
+		if (adj.PercentBps != 0) == (adj.AmountCents != 0) {
+			return status.Errorf(codes.InvalidArgument, "%s %q needs exactly one of a percentage or an amount", kind, adj.Name)
+		}
+		if adj.PercentBps < 0 || adj.PercentBps > 10000 {
+			return status.Errorf(codes.InvalidArgument, "%s %q percentage must be between 0 and 100%%", kind, adj.Name)
+		}
+		if adj.AmountCents < 0 || adj.AmountCents > maxPricingCents {
+			return status.Errorf(codes.InvalidArgument, "%s %q amount is out of range", kind, adj.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCheckPricing(t *testing.T) {
+	fixed := func(edit func(p *model.Pricing)) *model.Pricing {
+		p := &model.Pricing{Currency: "usd", Type: model.PricingFixed, FixedPriceCents: 10_000}
+		if edit != nil {
+			edit(p)
+		}
+		return p
+	}
+	tests := []struct {
+		name    string
+		pricing *model.Pricing
+		wantErr string
+	}{
+		{"fixed", fixed(nil), ""},
+		{"hourly", &model.Pricing{Currency: "EUR", Type: model.PricingHourly, HourlyRateCents: 8_000, EstimatedHours: 1.25}, ""},
+		{"free line item", fixed(func(p *model.Pricing) {
+			p.LineItems = []model.LineItem{{Description: "Kickoff call", Quantity: 1}}
+		}), ""},
+		{"near-total discount with a flat tax", fixed(func(p *model.Pricing) {
+			p.Discounts = []model.Adjustment{{Name: "Waived", PercentBps: 9_999}}
+			p.Taxes = []model.Adjustment{{Name: "Levy", AmountCents: 100}}
+		}), ""},
+
+		{"bad currency", fixed(func(p *model.Pricing) { p.Currency = "dollars" }), "3-letter ISO 4217"},
+		{"unknown type", fixed(func(p *model.Pricing) { p.Type = "retainer" }), "pricing type must be"},
+		{"negative fixed price", fixed(func(p *model.Pricing) { p.FixedPriceCents = -100 }), "fixed price must be positive"},
+		{"fixed with hours", fixed(func(p *model.Pricing) { p.EstimatedHours = 3 }), "cannot carry an hourly rate"},
+		{"negative hourly rate", &model.Pricing{Currency: "USD", Type: model.PricingHourly, HourlyRateCents: -8_000, EstimatedHours: 2}, "positive rate"},
+		{"hourly with fixed price", &model.Pricing{Currency: "USD", Type: model.PricingHourly, HourlyRateCents: 8_000, EstimatedHours: 2, FixedPriceCents: 1}, "cannot carry a fixed price"},
+		{"amount too large", fixed(func(p *model.Pricing) { p.FixedPriceCents = maxPricingCents + 1 }), "too large"},
+		{"line item without description", fixed(func(p *model.Pricing) {
+			p.LineItems = []model.LineItem{{Description: " ", Quantity: 1, UnitPriceCents: 100}}
+		}), "needs a description"},
+		{"negative line item price", fixed(func(p *model.Pricing) {
+			p.LineItems = []model.LineItem{{Description: "Refund", Quantity: 1, UnitPriceCents: -500}}
+		}), "non-negative unit price"},
+		{"zero quantity", fixed(func(p *model.Pricing) {
+			p.LineItems = []model.LineItem{{Description: "Hosting", Quantity: 0, UnitPriceCents: 500}}
+		}), "positive quantity"},
+		{"adjustment without a name", fixed(func(p *model.Pricing) {
+			p.Discounts = []model.Adjustment{{PercentBps: 500}}
+		}), "discount 1 needs a name"},
+		{"percentage and amount", fixed(func(p *model.Pricing) {
+			p.Taxes = []model.Adjustment{{Name: "VAT", PercentBps: 2_000, AmountCents: 100}}
+		}), "exactly one of"},
+		{"neither percentage nor amount", fixed(func(p *model.Pricing) {
+			p.Taxes = []model.Adjustment{{Name: "VAT"}}
+		}), "exactly one of"},
+		{"negative percentage", fixed(func(p *model.Pricing) {
+			p.Discounts = []model.Adjustment{{Name: "Odd", PercentBps: -100}}
+		}), "between 0 and 100%"},
+		{"percentage over 100", fixed(func(p *model.Pricing) {
+			p.Taxes = []model.Adjustment{{Name: "VAT", PercentBps: 10_001}}
+		}), "between 0 and 100%"},
+		{"negative amount", fixed(func(p *model.Pricing) {
+			p.Discounts = []model.Adjustment{{Name: "Credit", AmountCents: -100}}
+		}), "amount is out of range"},
+		{"discounts exceed the subtotal", fixed(func(p *model.Pricing) {
+			p.Discounts = []model.Adjustment{{Name: "Half", PercentBps: 5_000}, {Name: "Voucher", AmountCents: 6_000}}
+		}), "exceed the subtotal"},
+		{"zero total", fixed(func(p *model.Pricing) {
+			p.Discounts = []model.Adjustment{{Name: "Waived", PercentBps: 10_000}}
+		}), "total must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPricing(tt.pricing)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkPricing: %v", err)
+				}
+				return
+			}
+			wantCode(t, err, codes.InvalidArgument)
+			if err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckPricingNormalizesAndComputesTotals(t *testing.T) {
+	p := &model.Pricing{
+		Currency:        " usd ",
+		Type:            model.PricingFixed,
+		FixedPriceCents: 1_000,
+		Taxes:           []model.Adjustment{{Name: "VAT", PercentBps: 125}},
+	}
+	if err := checkPricing(p); err != nil {
+		t.Fatalf("checkPricing: %v", err)
+	}
+	if p.Currency != "USD" {
+		t.Errorf("currency = %q, want USD", p.Currency)
+	}
+	if p.TaxCents != 13 || p.TotalCents != 1_013 {
+		t.Errorf("tax %d, total %d; want 12.5 cents rounded to 13", p.TaxCents, p.TotalCents)
+	}
+}
@@ -5,61 +5,359 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"time"
+	"strconv"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/diff"
 	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/status"
     "google.golang.org/grpc/codes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"github.com/Prototype-1/freelanceX_proposal_service/internal/repository"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/storage"
 )
 
 type ProposalService struct {
-	repo *repository.ProposalRepository
+	repo             Repository
+	blobs            storage.BlobStore
+	attachmentLimits AttachmentLimits
+	maxListPageSize  int64
 }
 
-func NewProposalService(repo *repository.ProposalRepository) *ProposalService {
-	return &ProposalService{repo: repo}
+func NewProposalService(repo Repository, blobs storage.BlobStore, attachmentLimits AttachmentLimits, maxListPageSize int64) *ProposalService {
+	return &ProposalService{repo: repo, blobs: blobs, attachmentLimits: attachmentLimits, maxListPageSize: maxListPageSize}
 }
 
-func (s *ProposalService) CreateProposal(ctx context.Context, proposal model.Proposal) (*model.Proposal, error) {
+func (s *ProposalService) CreateProposal(ctx context.Context, proposal model.Proposal, actor model.Actor) (*model.Proposal, error) {
 	log.Printf("Creating proposal with title: %s, content: %s, sections: %+v", proposal.Title, proposal.Content, proposal.Sections)
 
 	if proposal.ClientID == "" || proposal.FreelancerID == "" || proposal.Title == "" {
 		return nil, errors.New("missing required fields")
 	}
-	return s.repo.CreateProposal(ctx, proposal)
+	if proposal.FreelancerID != actor.UserID {
+		return nil, status.Error(codes.PermissionDenied, "freelancers can only create proposals for themselves")
+	}
+	for i := range proposal.Sections {
+		proposal.Sections[i].ID = model.NewSectionID()
+	}
+	if err := prepareSections(proposal.Sections); err != nil {
+		return nil, err
+	}
+	if err := prepareContent(&proposal); err != nil {
+		return nil, err
+	}
+	if proposal.Pricing != nil {
+		if err := checkPricing(proposal.Pricing); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkMilestones(proposal.Milestones, proposal.Pricing, proposal.Deadline); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateProposal(ctx, proposal, model.OutboxEvent{EventType: EventProposalCreated, Actor: actor})
 }
 
-func (s *ProposalService) GetProposalByID(ctx context.Context, id string) (*model.Proposal, error) {
-	return s.repo.GetProposalByID(ctx, id)
+func (s *ProposalService) GetProposalByID(ctx context.Context, id string, actor model.Actor) (*model.Proposal, error) {
+	proposal, err := s.loadProposal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProposalAccess(proposal, actor); err != nil {
+		return nil, err
+	}
+	proposal.Sections = model.WithSectionIDs(proposal.Sections)
+	return proposal, nil
 }
 
-func (s *ProposalService) UpdateProposal(ctx context.Context, id string, updatedProposal model.Proposal) (*model.Proposal, error) {
+// loadProposal reads a proposal, reporting a malformed id as InvalidArgument
+// and a missing proposal as NotFound.
+func (s *ProposalService) loadProposal(ctx context.Context, id string) (*model.Proposal, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid proposal ID %q", id)
+	}
+	proposal, err := s.repo.GetProposalByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "proposal %s not found", id)
+		}
+		return nil, err
+	}
+	return proposal, nil
+}
 
-	if !updatedProposal.Deadline.IsZero() && updatedProposal.Deadline.Before(time.Now()) {
-		return nil, fmt.Errorf("cannot set the deadline to a past date")
+// checkProposalAccess allows freelancers and clients to reach only the
+// proposals they are a party to.
+func checkProposalAccess(proposal *model.Proposal, actor model.Actor) error {
+	switch actor.Role {
+	case model.RoleAdmin, model.RoleSystem:
+		return nil
+	case model.RoleFreelancer:
+		if actor.UserID != "" && proposal.FreelancerID == actor.UserID {
+			return nil
+		}
+	case model.RoleClient:
+		if actor.UserID != "" && proposal.ClientID == actor.UserID {
+			return nil
+		}
 	}
-	validStatuses := map[string]bool{
-		"draft":    true,
-		"sent":     true,
-		"accepted": true,
-		"rejected": true,
-		"expired": true,
+	return status.Error(codes.PermissionDenied, "you are not a party to this proposal")
+}
+
+// UpdateProposal changes the listed fields of a proposal. With no fields
+// listed, every field set on updatedProposal is changed and empty ones are
+// left alone. updatedProposal.Version is required and must be the current
+// version, otherwise the update fails with Aborted.
+func (s *ProposalService) UpdateProposal(ctx context.Context, id string, updatedProposal model.Proposal, fields []string, actor model.Actor) (*model.Proposal, error) {
+	if updatedProposal.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version is required to update a proposal")
 	}
-	
-	if updatedProposal.Status != "" && !validStatuses[updatedProposal.Status] {
+	if len(fields) == 0 {
+		fields = setFields(updatedProposal)
+	}
+	masked := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !updatableFields[field] {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update field %q", field)
+		}
+		masked[field] = true
+	}
+	// The format is stored with the content, so a format change writes the
+	// content too; alone, it rewrites the current content in the new format.
+	formatMasked := masked[model.FieldContentFormat]
+	formatOnly := formatMasked && !masked[model.FieldContent]
+	if formatMasked {
+		delete(masked, model.FieldContentFormat)
+		masked[model.FieldContent] = true
+		fields = withoutField(fields, model.FieldContentFormat)
+		if formatOnly {
+			fields = append(fields, model.FieldContent)
+		}
+	}
+
+	if masked[model.FieldTitle] && updatedProposal.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title cannot be empty")
+	}
+	if masked[model.FieldStatus] && updatedProposal.Status == "" {
+		return nil, status.Error(codes.InvalidArgument, "status cannot be empty")
+	}
+	if masked[model.FieldDeadline] {
+		if updatedProposal.Deadline.IsZero() {
+			return nil, status.Error(codes.InvalidArgument, "deadline cannot be empty")
+		}
+		if updatedProposal.Deadline.Before(time.Now()) {
+			return nil, fmt.Errorf("cannot set the deadline to a past date")
+		}
+	}
+
+	if masked[model.FieldPricing] && updatedProposal.Pricing != nil {
+		if err := checkPricing(updatedProposal.Pricing); err != nil {
+			return nil, err
+		}
+	}
+
+	if masked[model.FieldStatus] && !knownStatuses[updatedProposal.Status] {
 		return nil, fmt.Errorf("invalid status: %s", updatedProposal.Status)
 	}
 
+	current, err := s.GetProposalByID(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if masked[model.FieldSections] {
+		sections, err := replacementSections(updatedProposal.Sections, current.Sections)
+		if err != nil {
+			return nil, err
+		}
+		updatedProposal.Sections = sections
+		// Like a section edit, new sections regenerate the content unless it
+		// is written too.
+		if !masked[model.FieldContent] {
+			updatedProposal.Content = model.ContentFromSections(sections)
+			updatedProposal.ContentFormat = richtext.FormatMarkdown
+			masked[model.FieldContent] = true
+			fields = append(fields, model.FieldContent)
+		}
+	}
+
+	// Content written without a format keeps the one it had.
+	if masked[model.FieldContent] {
+		if formatOnly {
+			updatedProposal.Content = current.Content
+		}
+		if updatedProposal.ContentFormat == "" && !formatMasked {
+			updatedProposal.ContentFormat = current.ContentFormat
+		}
+		if err := prepareContent(&updatedProposal); err != nil {
+			return nil, err
+		}
+	}
+
+	editsContent := (masked[model.FieldTitle] && updatedProposal.Title != current.Title) ||
+		(masked[model.FieldContent] && (updatedProposal.Content != current.Content || updatedProposal.ContentFormat != current.ContentFormat)) ||
+		(masked[model.FieldSections] && !reflect.DeepEqual(updatedProposal.Sections, current.Sections)) ||
+		(masked[model.FieldDeadline] && !updatedProposal.Deadline.Equal(current.Deadline)) ||
+		(masked[model.FieldPricing] && !reflect.DeepEqual(updatedProposal.Pricing, current.Pricing)) ||
+		(masked[model.FieldMilestones] && !reflect.DeepEqual(updatedProposal.Milestones, current.Milestones)) ||
+		(masked[model.FieldAttachments] && !reflect.DeepEqual(updatedProposal.Attachments, current.Attachments))
+	if editsContent && !isEditableStatus(current.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot edit a proposal in status %s", current.Status)
+	}
+
+	// Fields outside the mask keep their current values so the events below
+	// only describe what actually changes.
+	if !masked[model.FieldTitle] {
+		updatedProposal.Title = current.Title
+	}
+	if !masked[model.FieldContent] {
+		updatedProposal.Content = current.Content
+		updatedProposal.ContentFormat = current.ContentFormat
+	}
+	if !masked[model.FieldStatus] {
+		updatedProposal.Status = current.Status
+	}
+	if !masked[model.FieldDeadline] {
+		updatedProposal.Deadline = time.Time{}
+	}
+	if !masked[model.FieldSections] {
+		updatedProposal.Sections = nil
+	}
+	if !masked[model.FieldPricing] {
+		updatedProposal.Pricing = current.Pricing
+	}
+	if !masked[model.FieldMilestones] {
+		updatedProposal.Milestones = current.Milestones
+	}
+	if !masked[model.FieldAttachments] {
+		updatedProposal.Attachments = current.Attachments
+	}
+
+	// Milestones must still add up to the total and fit before the deadline
+	// after any change to either.
+	if masked[model.FieldPricing] || masked[model.FieldDeadline] || masked[model.FieldMilestones] {
+		deadline := current.Deadline
+		if masked[model.FieldDeadline] {
+			deadline = updatedProposal.Deadline
+		}
+		if err := checkMilestones(updatedProposal.Milestones, updatedProposal.Pricing, deadline); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkStatusTransition(current.Status, updatedProposal.Status, actor.Role); err != nil {
+		return nil, err
+	}
+
+	events := changeEvents(current, updatedProposal, actor)
+
 	updatedProposal.UpdatedAt = time.Now()
-	return s.repo.UpdateProposal(ctx, id, updatedProposal)
+	proposal, err := s.repo.UpdateProposal(ctx, id, updatedProposal, fields, events...)
+	if err != nil {
+		var conflict *repository.VersionConflictError
+		if errors.As(err, &conflict) {
+			return nil, versionConflictStatus(conflict)
+		}
+		return nil, err
+	}
+	return proposal, nil
+}
+
+var updatableFields = map[string]bool{
+	model.FieldTitle:       true,
+	model.FieldContent:     true,
+	model.FieldStatus:      true,
+	model.FieldDeadline:    true,
+	model.FieldSections:    true,
+	model.FieldContractID:  true,
+	model.FieldPricing:     true,
+	model.FieldMilestones:  true,
+	model.FieldAttachments: true,
+
+	// Rewritten to FieldContent by UpdateProposal.
+	model.FieldContentFormat: true,
+}
+
+func withoutField(fields []string, field string) []string {
+	kept := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != field {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// setFields lists the fields of update that carry a value.
+func setFields(update model.Proposal) []string {
+	var fields []string
+	if update.Title != "" {
+		fields = append(fields, model.FieldTitle)
+	}
+	if update.Content != "" {
+		fields = append(fields, model.FieldContent)
+	}
+	if update.ContentFormat != "" {
+		fields = append(fields, model.FieldContentFormat)
+	}
+	if update.Status != "" {
+		fields = append(fields, model.FieldStatus)
+	}
+	if !update.Deadline.IsZero() {
+		fields = append(fields, model.FieldDeadline)
+	}
+	if update.Sections != nil {
+		fields = append(fields, model.FieldSections)
+	}
+	if update.ContractID != "" {
+		fields = append(fields, model.FieldContractID)
+	}
+	if update.Pricing != nil {
+		fields = append(fields, model.FieldPricing)
+	}
+	if update.Milestones != nil {
+		fields = append(fields, model.FieldMilestones)
+	}
+	if update.Attachments != nil {
+		fields = append(fields, model.FieldAttachments)
+	}
+	return fields
 }
 
-func (s *ProposalService) SaveTemplate(ctx context.Context, template model.Template) (*model.Template, error) {
+// versionConflictStatus reports the stored version both in the message and as
+// ErrorInfo metadata so editors can offer to reload or merge.
+func versionConflictStatus(conflict *repository.VersionConflictError) error {
+	st := status.New(codes.Aborted, conflict.Error())
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: "VERSION_CONFLICT",
+		Domain: "proposal.freelancex",
+		Metadata: map[string]string{
+			"proposal_id":     conflict.ProposalID,
+			"current_version": strconv.Itoa(conflict.CurrentVersion),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func (s *ProposalService) SaveTemplate(ctx context.Context, template model.Template, actor model.Actor) (*model.Template, error) {
 	if template.OwnerID == "" || template.Title == "" {
 		return nil, errors.New("missing required fields for template")
 	}
+	if template.OwnerID != actor.UserID {
+		return nil, status.Error(codes.PermissionDenied, "freelancers can only save their own templates")
+	}
+	sections, err := orderSections(template.Sections)
+	if err != nil {
+		return nil, err
+	}
+	template.Sections = sections
+	if err := checkTemplateVariables(&template); err != nil {
+		return nil, err
+	}
 	now := time.Now()
 	template.CreatedAt = now
 	template.UpdatedAt = now
@@ -67,32 +365,240 @@ func (s *ProposalService) SaveTemplate(ctx context.Context, template model.Templ
 	return s.repo.SaveTemplate(ctx, template)
 }
 
-func (s *ProposalService) GetTemplatesForFreelancer(ctx context.Context, freelancerID string) ([]*model.Template, error) {
-	templates, err := s.repo.GetTemplatesForFreelancer(ctx, freelancerID)
+const (
+	defaultTemplatePageSize = 20
+	maxTemplatePageSize     = 100
+)
+
+// GetTemplatesForFreelancer returns one page of the freelancer's live
+// templates and their total count. A non-positive limit selects the default
+// page size.
+func (s *ProposalService) GetTemplatesForFreelancer(ctx context.Context, freelancerID string, skip, limit int64, actor model.Actor) ([]*model.Template, int64, error) {
+	if freelancerID != actor.UserID {
+		return nil, 0, status.Error(codes.PermissionDenied, "freelancers can only view their own templates")
+	}
+	if skip < 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "skip cannot be negative")
+	}
+	if limit <= 0 {
+		limit = defaultTemplatePageSize
+	}
+	if limit > maxTemplatePageSize {
+		limit = maxTemplatePageSize
+	}
+
+	templates, total, err := s.repo.GetTemplatesForFreelancer(ctx, freelancerID, skip, limit)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return templates, nil
+	return templates, total, nil
 }
 
-func (s *ProposalService) GetTemplateByID(ctx context.Context, id primitive.ObjectID) (*model.Template, error) {
+func (s *ProposalService) GetTemplateByID(ctx context.Context, id primitive.ObjectID, actor model.Actor) (*model.Template, error) {
 	template, err := s.repo.GetTemplateByID(ctx, id)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, status.Errorf(codes.NotFound, "template not found")
 		}
 		return nil, err
 	}
+	if template.OwnerID != actor.UserID {
+		return nil, status.Error(codes.PermissionDenied, "template belongs to another freelancer")
+	}
 	return template, nil
 }
 
-func (s *ProposalService) GetProposals(ctx context.Context, filters map[string]interface{}, skip, limit int64) ([]*model.Proposal, error) {
-	if filters == nil {
-		filters = make(map[string]interface{})
+// UpdateTemplate edits a live template owned by the caller. A positive
+// expectedVersion guards against overwriting a concurrent edit.
+func (s *ProposalService) UpdateTemplate(ctx context.Context, id primitive.ObjectID, update model.Template, expectedVersion int, actor model.Actor) (*model.Template, error) {
+	if update.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "template title is required")
+	}
+	sections, err := orderSections(update.Sections)
+	if err != nil {
+		return nil, err
+	}
+	update.Sections = sections
+	if err := checkTemplateVariables(&update); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetTemplateByID(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if current.DeletedAt != nil {
+		return nil, status.Error(codes.NotFound, "template has been deleted")
+	}
+	if expectedVersion > 0 && expectedVersion != current.Version {
+		return nil, status.Errorf(codes.Aborted, "template %s has been modified: current version is %d", id.Hex(), current.Version)
+	}
+
+	updated, err := s.repo.UpdateTemplate(ctx, id, update, current.Version)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.Aborted, "template %s was modified or deleted concurrently", id.Hex())
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteTemplate soft-deletes a template owned by the caller. Proposals
+// created from it keep resolving it.
+func (s *ProposalService) DeleteTemplate(ctx context.Context, id primitive.ObjectID, actor model.Actor) error {
+	if _, err := s.GetTemplateByID(ctx, id, actor); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTemplate(ctx, id); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.NotFound, "template has already been deleted")
+		}
+		return err
+	}
+	return nil
+}
+
+// ListProposalsByTemplate pages through the proposals created from a template
+// owned by the caller. Deleted templates can still be queried.
+func (s *ProposalService) ListProposalsByTemplate(ctx context.Context, templateID primitive.ObjectID, skip, limit int64, actor model.Actor) ([]*model.Proposal, int64, error) {
+	if _, err := s.GetTemplateByID(ctx, templateID, actor); err != nil {
+		return nil, 0, err
+	}
+	if skip < 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "skip cannot be negative")
+	}
+	if limit <= 0 {
+		limit = defaultTemplatePageSize
+	}
+	if limit > maxTemplatePageSize {
+		limit = maxTemplatePageSize
+	}
+
+	proposals, total, err := s.repo.ListProposalsByTemplate(ctx, templateID, skip, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return proposals, total, nil
+}
+
+// GetTemplateStats reports usage and acceptance for each of the freelancer's
+// templates that has produced a proposal, or for a single template when
+// templateID is set.
+func (s *ProposalService) GetTemplateStats(ctx context.Context, freelancerID string, templateID *primitive.ObjectID, actor model.Actor) ([]model.TemplateStats, error) {
+	if freelancerID != actor.UserID {
+		return nil, status.Error(codes.PermissionDenied, "freelancers can only view their own template statistics")
+	}
+	if templateID != nil {
+		if _, err := s.GetTemplateByID(ctx, *templateID, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	stats, err := s.repo.GetTemplateStats(ctx, freelancerID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if templateID != nil && len(stats) == 0 {
+		stats = []model.TemplateStats{{TemplateID: *templateID, ByStatus: map[string]int64{}}}
+	}
+	return stats, nil
+}
+
+// GetProposalRevisions returns the current proposal along with its superseded
+// versions, newest first.
+func (s *ProposalService) GetProposalRevisions(ctx context.Context, id string, actor model.Actor) (*model.Proposal, []*model.ProposalRevision, error) {
+	current, err := s.GetProposalByID(ctx, id, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	revisions, err := s.repo.GetProposalRevisions(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+	}
+	for _, revision := range revisions {
+		revision.Sections = model.WithSectionIDs(revision.Sections)
+	}
+	return current, revisions, nil
+}
+
+// GetProposalVersion returns the proposal as it was at the given version. The
+// current version is served from the proposal itself since revisions only hold
+// superseded versions.
+func (s *ProposalService) GetProposalVersion(ctx context.Context, id string, version int, actor model.Actor) (*model.ProposalRevision, error) {
+	if version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version must be positive")
+	}
+
+	current, err := s.GetProposalByID(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if version == current.Version {
+		revision := model.RevisionOf(current)
+		return &revision, nil
+	}
+	if version > current.Version {
+		return nil, status.Errorf(codes.NotFound, "proposal %s has no version %d", id, version)
+	}
+
+	revision, err := s.repo.GetProposalRevision(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "proposal %s has no version %d", id, version)
+		}
+		return nil, err
+	}
+	revision.Sections = model.WithSectionIDs(revision.Sections)
+	return revision, nil
+}
+
+// RestoreRevision writes the content of an older version as a new version.
+// Status is lifecycle state rather than content and is left untouched; the old
+// deadline is only restored while it still lies in the future. Attachments are
+// kept as they are, since the content of removed ones has been deleted.
+func (s *ProposalService) RestoreRevision(ctx context.Context, id string, version, expectedVersion int, actor model.Actor) (*model.Proposal, error) {
+	revision, err := s.GetProposalVersion(ctx, id, version, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := revision.Sections
+	if sections == nil {
+		sections = []model.Section{}
+	}
+
+	restored := model.Proposal{
+		Title:         revision.Title,
+		Content:       revision.Content,
+		ContentFormat: revision.ContentFormat,
+		Sections:      sections,
+		Pricing:       revision.Pricing,
+		Milestones:    revision.Milestones,
+		Version:       expectedVersion,
+	}
+	if restored.Milestones == nil {
+		restored.Milestones = []model.Milestone{}
+	}
+	fields := []string{model.FieldTitle, model.FieldContent, model.FieldSections, model.FieldPricing, model.FieldMilestones}
+	if revision.Deadline.After(time.Now()) {
+		restored.Deadline = revision.Deadline
+		fields = append(fields, model.FieldDeadline)
+	}
+
+	return s.UpdateProposal(ctx, id, restored, fields, actor)
+}
+
+func (s *ProposalService) DiffProposalVersions(ctx context.Context, id string, fromVersion, toVersion int, actor model.Actor) (*diff.ProposalDiff, error) {
+	from, err := s.GetProposalVersion(ctx, id, fromVersion, actor)
+	if err != nil {
+		return nil, err
 	}
-	proposals, err := s.repo.GetProposals(ctx, filters, skip, limit)
+	to, err := s.GetProposalVersion(ctx, id, toVersion, actor)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve proposals: %w", err)
+		return nil, err
 	}
-	return proposals, nil
+
+	d := diff.Proposals(from, to)
+	return &d, nil
 }
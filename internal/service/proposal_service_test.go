@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/diff"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var freelancer = model.Actor{UserID: "freelancer-1", Role: model.RoleFreelancer}
+
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("error = %v, want code %s", err, code)
+	}
+}
+
+func TestUpdateProposalReplacesSections(t *testing.T) {
+	repo := newMemRepository()
+	id := repo.add(model.Proposal{
+		ClientID:     "client-1",
+		FreelancerID: freelancer.UserID,
+		Title:        "Logo design",
+		Status:       model.StatusDraft,
+		Sections: []model.Section{
+			{ID: "scope", Heading: "Scope", Body: "Three concepts", Format: richtext.FormatMarkdown, Order: 1},
+			{ID: "terms", Heading: "Terms", Body: "Net 30", Format: richtext.FormatMarkdown, Order: 2},
+		},
+	})
+	s := NewProposalService(repo, nil, AttachmentLimits{}, 50)
+
+	sections := []model.Section{
+		{ID: "terms", Heading: "Terms", Body: "Net 15"},
+		{Heading: "Timeline", Body: "<p>Two <b>weeks</b></p>", Format: richtext.FormatHTML},
+	}
+	updated, err := s.UpdateProposal(context.Background(), id, model.Proposal{Sections: sections, Version: 1}, []string{model.FieldSections}, freelancer)
+	if err != nil {
+		t.Fatalf("UpdateProposal: %v", err)
+	}
+
+	if len(updated.Sections) != 2 {
+		t.Fatalf("sections = %+v, want 2", updated.Sections)
+	}
+	if sec := updated.Sections[0]; sec.ID != "terms" || sec.Body != "Net 15" || sec.Order != 1 {
+		t.Errorf("first section = %+v, want terms kept by id and renumbered", sec)
+	}
+	if sec := updated.Sections[1]; sec.ID == "" || sec.Format != richtext.FormatHTML || sec.Order != 2 {
+		t.Errorf("new section = %+v, want a fresh id and the html format", sec)
+	}
+	if !strings.Contains(updated.Content, "Net 15") || strings.Contains(updated.Content, "Three concepts") {
+		t.Errorf("content %q was not regenerated from the sections", updated.Content)
+	}
+
+	_, err = s.UpdateProposal(context.Background(), id, model.Proposal{
+		Sections: []model.Section{{ID: "unknown", Heading: "Scope"}},
+		Version:  updated.Version,
+	}, []string{model.FieldSections}, freelancer)
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestUpdateProposalChangesContentFormatAlone(t *testing.T) {
+	repo := newMemRepository()
+	id := repo.add(model.Proposal{
+		ClientID:      "client-1",
+		FreelancerID:  freelancer.UserID,
+		Title:         "Logo design",
+		Content:       "<p>Hello</p><script>alert(1)</script>",
+		ContentFormat: richtext.FormatPlain,
+		Status:        model.StatusDraft,
+	})
+	s := NewProposalService(repo, nil, AttachmentLimits{}, 50)
+
+	updated, err := s.UpdateProposal(context.Background(), id, model.Proposal{ContentFormat: richtext.FormatHTML, Version: 1}, []string{model.FieldContentFormat}, freelancer)
+	if err != nil {
+		t.Fatalf("UpdateProposal: %v", err)
+	}
+	if updated.ContentFormat != richtext.FormatHTML || updated.Content != "<p>Hello</p>" {
+		t.Errorf("content = %q in %s, want the current content sanitized as html", updated.Content, updated.ContentFormat)
+	}
+	if updated.Title != "Logo design" {
+		t.Errorf("title changed to %q", updated.Title)
+	}
+}
+
+func TestUpdateProposalRequiresVersion(t *testing.T) {
+	repo := newMemRepository()
+	id := repo.add(model.Proposal{ClientID: "client-1", FreelancerID: freelancer.UserID, Title: "Logo design", Status: model.StatusDraft})
+	s := NewProposalService(repo, nil, AttachmentLimits{}, 50)
+
+	_, err := s.UpdateProposal(context.Background(), id, model.Proposal{Title: "Brand identity"}, []string{model.FieldTitle}, freelancer)
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = s.UpdateProposal(context.Background(), id, model.Proposal{Title: "Brand identity", Version: 2}, []string{model.FieldTitle}, freelancer)
+	wantCode(t, err, codes.Aborted)
+
+	if got := repo.get(id); got.Title != "Logo design" || got.Version != 1 {
+		t.Errorf("proposal = %q at version %d, want it unchanged", got.Title, got.Version)
+	}
+}
+
+func TestDiffProposalVersionsMatchesSectionsByID(t *testing.T) {
+	repo := newMemRepository()
+	id := repo.add(model.Proposal{
+		ClientID:     "client-1",
+		FreelancerID: freelancer.UserID,
+		Title:        "Logo design",
+		Status:       model.StatusDraft,
+		Sections: []model.Section{
+			{ID: "scope", Heading: "Scope", Body: "Three concepts", Order: 1},
+			{ID: "terms", Heading: "Terms", Body: "Net 30", Order: 2},
+		},
+	})
+	s := NewProposalService(repo, nil, AttachmentLimits{}, 50)
+
+	sections := []model.Section{
+		{ID: "terms", Heading: "Payment terms", Body: "Net 30"},
+		{ID: "scope", Heading: "Scope", Body: "Five concepts"},
+	}
+	if _, err := s.UpdateProposal(context.Background(), id, model.Proposal{Sections: sections, Version: 1}, []string{model.FieldSections}, freelancer); err != nil {
+		t.Fatalf("UpdateProposal: %v", err)
+	}
+
+	d, err := s.DiffProposalVersions(context.Background(), id, 1, 2, freelancer)
+	if err != nil {
+		t.Fatalf("DiffProposalVersions: %v", err)
+	}
+	if len(d.Sections) != 2 {
+		t.Fatalf("section changes = %+v, want the scope edit and the terms rename", d.Sections)
+	}
+	if c := d.Sections[0]; c.SectionID != "scope" || c.Change != diff.SectionChanged || len(c.Lines) != 2 {
+		t.Errorf("scope change = %+v", c)
+	}
+	if c := d.Sections[1]; c.SectionID != "terms" || c.FromHeading != "Terms" || len(c.Lines) != 0 {
+		t.Errorf("terms change = %+v, want a rename only", c)
+	}
+
+	same, err := s.DiffProposalVersions(context.Background(), id, 2, 2, freelancer)
+	if err != nil {
+		t.Fatalf("DiffProposalVersions: %v", err)
+	}
+	if len(same.Fields) != 0 || len(same.Sections) != 0 || len(same.Content) != 0 {
+		t.Errorf("diff of a version with itself = %+v", same)
+	}
+
+	_, err = s.DiffProposalVersions(context.Background(), id, 1, 3, freelancer)
+	wantCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository is the storage the service runs on. *repository.ProposalRepository
+// implements it against MongoDB; tests substitute an in-memory one.
+type Repository interface {
+	CreateProposal(ctx context.Context, proposal model.Proposal, events ...model.OutboxEvent) (*model.Proposal, error)
+	GetProposalByID(ctx context.Context, proposalID string) (*model.Proposal, error)
+	UpdateProposal(ctx context.Context, proposalID string, update model.Proposal, fields []string, events ...model.OutboxEvent) (*model.Proposal, error)
+	ArchiveProposal(ctx context.Context, proposal *model.Proposal, deletedUserID string, events ...model.OutboxEvent) (*model.Proposal, error)
+	GetProposalRevisions(ctx context.Context, proposalID string) ([]*model.ProposalRevision, error)
+	GetProposalRevision(ctx context.Context, proposalID string, version int) (*model.ProposalRevision, error)
+	GetProposals(ctx context.Context, filter model.ProposalFilter, page model.ProposalPage) ([]*model.Proposal, int64, error)
+	GetOpenProposalsForJob(ctx context.Context, jobID string) ([]*model.Proposal, error)
+	GetActiveProposalsForUser(ctx context.Context, userID string) ([]*model.Proposal, error)
+	GetProposalsDueForExpiry(ctx context.Context, now time.Time) ([]*model.Proposal, error)
+
+	SaveTemplate(ctx context.Context, template model.Template) (*model.Template, error)
+	GetTemplateByID(ctx context.Context, id primitive.ObjectID) (*model.Template, error)
+	GetTemplatesForFreelancer(ctx context.Context, freelancerID string, skip, limit int64) ([]*model.Template, int64, error)
+	UpdateTemplate(ctx context.Context, id primitive.ObjectID, update model.Template, version int) (*model.Template, error)
+	DeleteTemplate(ctx context.Context, id primitive.ObjectID) error
+	ListProposalsByTemplate(ctx context.Context, templateID primitive.ObjectID, skip, limit int64) ([]*model.Proposal, int64, error)
+	GetTemplateStats(ctx context.Context, freelancerID string, templateID *primitive.ObjectID) ([]model.TemplateStats, error)
+
+	GetBrandingProfile(ctx context.Context, freelancerID string) (*model.BrandingProfile, error)
+	SaveBrandingProfile(ctx context.Context, profile model.BrandingProfile) (*model.BrandingProfile, *model.BrandingProfile, error)
+}
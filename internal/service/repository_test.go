@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memRepository keeps proposals in memory and mirrors the version checks of
+// the MongoDB repository. Methods it does not implement panic through the
+// embedded nil Repository.
+type memRepository struct {
+	Repository
+
+	mu        sync.Mutex
+	proposals map[string]*model.Proposal
+	revisions []model.ProposalRevision
+	events    []model.OutboxEvent
+	// updateErr, when set, fails every UpdateProposal.
+	updateErr error
+}
+
+func newMemRepository() *memRepository {
+	return &memRepository{proposals: make(map[string]*model.Proposal)}
+}
+
+// add stores p as version 1 and returns its id.
+func (r *memRepository) add(p model.Proposal) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p.ID = primitive.NewObjectID()
+	p.Version = 1
+	p.CreatedAt, p.UpdatedAt = time.Now(), time.Now()
+	r.proposals[p.ID.Hex()] = &p
+	return p.ID.Hex()
+}
+
+// get returns a copy of the stored proposal, or nil.
+func (r *memRepository) get(id string) *model.Proposal {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.proposals[id]
+	if !ok {
+		return nil
+	}
+	cp := *p
+	return &cp
+}
+
+func (r *memRepository) CreateProposal(ctx context.Context, proposal model.Proposal, events ...model.OutboxEvent) (*model.Proposal, error) {
+	id := r.add(proposal)
+	r.mu.Lock()
+	r.events = append(r.events, events...)
+	r.mu.Unlock()
+	return r.get(id), nil
+}
+
+func (r *memRepository) GetProposalByID(ctx context.Context, proposalID string) (*model.Proposal, error) {
+	if _, err := primitive.ObjectIDFromHex(proposalID); err != nil {
+		return nil, fmt.Errorf("invalid proposal ID: %w", err)
+	}
+	if p := r.get(proposalID); p != nil && p.ArchivedAt == nil {
+		return p, nil
+	}
+	return nil, fmt.Errorf("proposal with ID %s not found: %w", proposalID, mongo.ErrNoDocuments)
+}
+
+func (r *memRepository) UpdateProposal(ctx context.Context, proposalID string, update model.Proposal, fields []string, events ...model.OutboxEvent) (*model.Proposal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.updateErr != nil {
+		return nil, r.updateErr
+	}
+	p, err := r.lockedCheckVersion(proposalID, update.Version)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		switch field {
+		case model.FieldTitle:
+			p.Title = update.Title
+		case model.FieldContent:
+			p.Content, p.ContentFormat = update.Content, update.ContentFormat
+		case model.FieldStatus:
+			p.Status = update.Status
+		case model.FieldDeadline:
+			p.Deadline = update.Deadline
+		case model.FieldSections:
+			p.Sections = update.Sections
+		case model.FieldContractID:
+			p.ContractID = update.ContractID
+		case model.FieldPricing:
+			p.Pricing = update.Pricing
+		case model.FieldMilestones:
+			p.Milestones = update.Milestones
+		case model.FieldAttachments:
+			p.Attachments = update.Attachments
+		default:
+			return nil, fmt.Errorf("cannot update proposal field %q", field)
+		}
+	}
+	return r.lockedSave(p, events), nil
+}
+
+func (r *memRepository) ArchiveProposal(ctx context.Context, proposal *model.Proposal, deletedUserID string, events ...model.OutboxEvent) (*model.Proposal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, err := r.lockedCheckVersion(proposal.ID.Hex(), proposal.Version)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	p.ArchivedAt = &now
+	if p.ClientID == deletedUserID {
+		p.ClientID = model.DeletedUserID
+	}
+	if p.FreelancerID == deletedUserID {
+		p.FreelancerID = model.DeletedUserID
+	}
+	return r.lockedSave(p, events), nil
+}
+
+func (r *memRepository) GetOpenProposalsForJob(ctx context.Context, jobID string) ([]*model.Proposal, error) {
+	return r.find(func(p *model.Proposal) bool {
+		return p.JobID == jobID && (p.Status == model.StatusDraft || p.Status == model.StatusSent)
+	}), nil
+}
+
+func (r *memRepository) GetActiveProposalsForUser(ctx context.Context, userID string) ([]*model.Proposal, error) {
+	return r.find(func(p *model.Proposal) bool {
+		return p.ArchivedAt == nil && (p.ClientID == userID || p.FreelancerID == userID)
+	}), nil
+}
+
+func (r *memRepository) find(match func(*model.Proposal) bool) []*model.Proposal {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var found []*model.Proposal
+	for _, p := range r.proposals {
+		if match(p) {
+			cp := *p
+			found = append(found, &cp)
+		}
+	}
+	return found
+}
+
+// lockedCheckVersion returns a copy of the stored proposal to modify, failing
+// like the MongoDB repository when version is set and stale.
+func (r *memRepository) lockedCheckVersion(id string, version int) (*model.Proposal, error) {
+	stored, ok := r.proposals[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to decode updated proposal: %w", mongo.ErrNoDocuments)
+	}
+	if version > 0 && version != stored.Version {
+		return nil, &repository.VersionConflictError{ProposalID: id, CurrentVersion: stored.Version}
+	}
+	cp := *stored
+	return &cp, nil
+}
+
+func (r *memRepository) GetProposalRevision(ctx context.Context, proposalID string, version int) (*model.ProposalRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rev := range r.revisions {
+		if rev.ProposalID.Hex() == proposalID && rev.Version == version {
+			return &rev, nil
+		}
+	}
+	return nil, fmt.Errorf("revision %d of proposal %s not found: %w", version, proposalID, mongo.ErrNoDocuments)
+}
+
+// lockedSave stores p as the next version, snapshotting the one it replaces
+// like the MongoDB repository does.
+func (r *memRepository) lockedSave(p *model.Proposal, events []model.OutboxEvent) *model.Proposal {
+	r.revisions = append(r.revisions, model.RevisionOf(r.proposals[p.ID.Hex()]))
+	p.Version++
+	p.UpdatedAt = time.Now()
+	r.proposals[p.ID.Hex()] = p
+	r.events = append(r.events, events...)
+	cp := *p
+	return &cp
+}
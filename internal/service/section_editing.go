@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/repository"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/richtext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSectionEditAttempts bounds how often a section edit is re-applied after
+// losing a race with another edit of the same proposal.
+const maxSectionEditAttempts = 3
+
+// SectionEdit holds the fields of a section to change; nil fields are kept.
+type SectionEdit struct {
+	Heading *string
+	Body    *string
+	Format  *string
+}
+
+// AddSection inserts a section at the 1-based position, or appends it when
+// position is zero, and returns the updated proposal with the new section id.
+func (s *ProposalService) AddSection(ctx context.Context, id string, section model.Section, position, expectedVersion int, actor model.Actor) (*model.Proposal, string, error) {
+	section.Heading = strings.TrimSpace(section.Heading)
+	if section.Heading == "" {
+		return nil, "", status.Error(codes.InvalidArgument, "section heading is required")
+	}
+	if position < 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "position cannot be negative")
+	}
+	section.ID = model.NewSectionID()
+
+	proposal, err := s.editSections(ctx, id, expectedVersion, actor, func(sections []model.Section) ([]model.Section, error) {
+		at := position - 1
+		if position == 0 || at > len(sections) {
+			at = len(sections)
+		}
+		sections = append(sections, model.Section{})
+		copy(sections[at+1:], sections[at:])
+		sections[at] = section
+		return sections, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return proposal, section.ID, nil
+}
+
+// UpdateSection changes the heading and/or body of one section.
+func (s *ProposalService) UpdateSection(ctx context.Context, id, sectionID string, edit SectionEdit, expectedVersion int, actor model.Actor) (*model.Proposal, error) {
+	if edit.Heading != nil {
+		heading := strings.TrimSpace(*edit.Heading)
+		if heading == "" {
+			return nil, status.Error(codes.InvalidArgument, "section heading cannot be empty")
+		}
+		edit.Heading = &heading
+	}
+
+	return s.editSections(ctx, id, expectedVersion, actor, func(sections []model.Section) ([]model.Section, error) {
+		i, err := findSection(sections, sectionID)
+		if err != nil {
+			return nil, err
+		}
+		if edit.Heading != nil {
+			sections[i].Heading = *edit.Heading
+		}
+		if edit.Body != nil {
+			sections[i].Body = *edit.Body
+		}
+		if edit.Format != nil {
+			sections[i].Format = *edit.Format
+		}
+		return sections, nil
+	})
+}
+
+// ReorderSections puts the sections in the given order, which must name every
+// section exactly once.
+func (s *ProposalService) ReorderSections(ctx context.Context, id string, sectionIDs []string, expectedVersion int, actor model.Actor) (*model.Proposal, error) {
+	return s.editSections(ctx, id, expectedVersion, actor, func(sections []model.Section) ([]model.Section, error) {
+		if len(sectionIDs) != len(sections) {
+			return nil, status.Errorf(codes.InvalidArgument, "section_ids must list all %d sections exactly once", len(sections))
+		}
+		reordered := make([]model.Section, 0, len(sections))
+		used := make(map[string]bool, len(sectionIDs))
+		for _, sectionID := range sectionIDs {
+			if used[sectionID] {
+				return nil, status.Errorf(codes.InvalidArgument, "section %s is listed twice", sectionID)
+			}
+			used[sectionID] = true
+			i, err := findSection(sections, sectionID)
+			if err != nil {
+				return nil, err
+			}
+			reordered = append(reordered, sections[i])
+		}
+		return reordered, nil
+	})
+}
+
+// RemoveSection deletes one section.
+func (s *ProposalService) RemoveSection(ctx context.Context, id, sectionID string, expectedVersion int, actor model.Actor) (*model.Proposal, error) {
+	return s.editSections(ctx, id, expectedVersion, actor, func(sections []model.Section) ([]model.Section, error) {
+		i, err := findSection(sections, sectionID)
+		if err != nil {
+			return nil, err
+		}
+		return append(sections[:i], sections[i+1:]...), nil
+	})
+}
+
+// editSections applies one section change as a new proposal version and
+// regenerates the content from the sections. Without an expectedVersion a
+// lost race is retried against the latest version, which keeps concurrent
+// edits of other sections; with one, the conflict is returned to the caller.
+func (s *ProposalService) editSections(ctx context.Context, id string, expectedVersion int, actor model.Actor, apply func([]model.Section) ([]model.Section, error)) (*model.Proposal, error) {
+	if actor.Role != model.RoleFreelancer {
+		return nil, status.Error(codes.PermissionDenied, "only the freelancer can edit proposal sections")
+	}
+
+	var conflict *repository.VersionConflictError
+	for attempt := 0; attempt < maxSectionEditAttempts; attempt++ {
+		current, err := s.GetProposalByID(ctx, id, actor)
+		if err != nil {
+			return nil, err
+		}
+		if !isEditableStatus(current.Status) {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot edit a proposal in status %s", current.Status)
+		}
+		if expectedVersion > 0 && expectedVersion != current.Version {
+			return nil, versionConflictStatus(&repository.VersionConflictError{ProposalID: id, CurrentVersion: current.Version})
+		}
+
+		// A proposal written as free text becomes its first section so the
+		// regenerated content does not drop it.
+		sections := make([]model.Section, len(current.Sections))
+		copy(sections, current.Sections)
+		if len(sections) == 0 && current.Content != "" {
+			sections = append(sections, model.Section{ID: model.NewSectionID(), Heading: "Notes", Body: current.Content, Format: current.ContentFormat})
+		}
+
+		sections, err = apply(sections)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sections {
+			sections[i].Order = i + 1
+		}
+		if err := prepareSections(sections); err != nil {
+			return nil, err
+		}
+
+		update := model.Proposal{
+			Title:         current.Title,
+			Content:       model.ContentFromSections(sections),
+			ContentFormat: richtext.FormatMarkdown,
+			Status:        current.Status,
+			Sections:      sections,
+			Pricing:       current.Pricing,
+			Milestones:    current.Milestones,
+			Attachments:   current.Attachments,
+			Version:       current.Version,
+			UpdatedAt:     time.Now(),
+		}
+		fields := []string{model.FieldContent, model.FieldSections}
+		proposal, err := s.repo.UpdateProposal(ctx, id, update, fields, changeEvents(current, update, actor)...)
+		if err == nil {
+			return proposal, nil
+		}
+		if !errors.As(err, &conflict) {
+			return nil, err
+		}
+		if expectedVersion > 0 {
+			break
+		}
+	}
+	return nil, versionConflictStatus(conflict)
+}
+
+func findSection(sections []model.Section, sectionID string) (int, error) {
+	for i, sec := range sections {
+		if sec.ID == sectionID {
+			return i, nil
+		}
+	}
+	return -1, status.Errorf(codes.NotFound, "section %s not found", sectionID)
+}
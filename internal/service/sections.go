@@ -0,0 +1,132 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// orderSections validates sections and returns them sorted and numbered from
+// 1. When no section carries an order the list order is kept; otherwise every
+// section must carry a distinct positive order.
+func orderSections(sections []model.Section) ([]model.Section, error) {
+	ordered := make([]model.Section, len(sections))
+	copy(ordered, sections)
+
+	explicit := false
+	for _, sec := range ordered {
+		if sec.Order != 0 {
+			explicit = true
+			break
+		}
+	}
+
+	seen := make(map[int]bool, len(ordered))
+	for i, sec := range ordered {
+		if sec.Heading == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "section %d is missing a heading", i+1)
+		}
+		if !explicit {
+			continue
+		}
+		if sec.Order <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "section %q needs a positive order when other sections set one", sec.Heading)
+		}
+		if seen[sec.Order] {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate section order %d", sec.Order)
+		}
+		seen[sec.Order] = true
+	}
+
+	if explicit {
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Order < ordered[j].Order })
+	}
+	for i := range ordered {
+		ordered[i].Order = i + 1
+	}
+	if err := prepareSections(ordered); err != nil {
+		return nil, err
+	}
+	return ordered, nil
+}
+
+// replacementSections validates the sections replacing a proposal's current
+// ones. A section keeps the id it is sent with, which must belong to one of
+// the current sections; sections without an id are new and get one.
+func replacementSections(sections, current []model.Section) ([]model.Section, error) {
+	ordered, err := orderSections(sections)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(current))
+	for _, sec := range model.WithSectionIDs(current) {
+		known[sec.ID] = true
+	}
+	used := make(map[string]bool, len(ordered))
+	for i := range ordered {
+		sec := &ordered[i]
+		sec.Heading = strings.TrimSpace(sec.Heading)
+		if sec.ID == "" {
+			sec.ID = model.NewSectionID()
+			continue
+		}
+		if !known[sec.ID] {
+			return nil, status.Errorf(codes.InvalidArgument, "section %s is not on the proposal; leave the id empty for a new section", sec.ID)
+		}
+		if used[sec.ID] {
+			return nil, status.Errorf(codes.InvalidArgument, "section %s is listed twice", sec.ID)
+		}
+		used[sec.ID] = true
+	}
+	return ordered, nil
+}
+
+// MergeSections overlays caller sections on a template's: a caller section
+// whose heading matches a template section (ignoring case and surrounding
+// space) replaces that section's body and format in place, and any other caller section
+// is appended. The result is numbered from 1.
+func (s *ProposalService) MergeSections(base, overrides []model.Section) ([]model.Section, error) {
+	base, err := orderSections(base)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make([]model.Section, len(base))
+	copy(merged, base)
+
+	index := make(map[string]int, len(merged))
+	for i, sec := range merged {
+		key := sectionKey(sec.Heading)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	for _, sec := range overrides {
+		sec.Heading = strings.TrimSpace(sec.Heading)
+		if sec.Heading == "" {
+			return nil, status.Error(codes.InvalidArgument, "every section needs a heading")
+		}
+		if i, ok := index[sectionKey(sec.Heading)]; ok {
+			merged[i].Body = sec.Body
+			merged[i].Format = sec.Format
+			continue
+		}
+		index[sectionKey(sec.Heading)] = len(merged)
+		sec.Order = 0
+		merged = append(merged, sec)
+	}
+
+	for i := range merged {
+		merged[i].Order = i + 1
+	}
+	return merged, nil
+}
+
+func sectionKey(heading string) string {
+	return strings.ToLower(strings.TrimSpace(heading))
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusTransitions maps a current status to the statuses it may move to and
+// the roles allowed to make each move. Statuses without an entry are terminal.
+var statusTransitions = map[string]map[string][]string{
+	model.StatusDraft: {
+		model.StatusSent:      {model.RoleFreelancer, model.RoleSystem},
+		model.StatusWithdrawn: {model.RoleFreelancer, model.RoleSystem},
+		model.StatusExpired:   {model.RoleSystem},
+	},
+	model.StatusSent: {
+		model.StatusAccepted:  {model.RoleClient},
+		model.StatusRejected:  {model.RoleClient},
+		model.StatusWithdrawn: {model.RoleFreelancer, model.RoleSystem},
+		model.StatusExpired:   {model.RoleSystem},
+	},
+	model.StatusAccepted: {
+		model.StatusContracted: {model.RoleSystem},
+	},
+}
+
+var knownStatuses = map[string]bool{
+	model.StatusDraft:      true,
+	model.StatusSent:       true,
+	model.StatusAccepted:   true,
+	model.StatusRejected:   true,
+	model.StatusWithdrawn:  true,
+	model.StatusExpired:    true,
+	model.StatusContracted: true,
+}
+
+// checkStatusTransition reports whether role may move a proposal from one
+// status to another.
+func checkStatusTransition(from, to, role string) error {
+	if from == to {
+		return nil
+	}
+	roles, ok := statusTransitions[from][to]
+	if !ok {
+		return status.Errorf(codes.FailedPrecondition, "illegal status transition %s -> %s", from, to)
+	}
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "role %q may not move a proposal from %s to %s", role, from, to)
+}
+
+// isEditableStatus reports whether a proposal's content may still change.
+// Once a client has decided, the text they decided on is frozen.
+func isEditableStatus(s string) bool {
+	return s == model.StatusDraft || s == model.StatusSent
+}
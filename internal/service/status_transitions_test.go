@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckStatusTransition(t *testing.T) {
+	type move struct{ from, to, role string }
+	allowed := map[move]bool{
+		{model.StatusDraft, model.StatusSent, model.RoleFreelancer}:      true,
+		{model.StatusDraft, model.StatusSent, model.RoleSystem}:          true,
+		{model.StatusDraft, model.StatusWithdrawn, model.RoleFreelancer}: true,
+		{model.StatusDraft, model.StatusWithdrawn, model.RoleSystem}:     true,
+		{model.StatusDraft, model.StatusExpired, model.RoleSystem}:       true,
+		{model.StatusSent, model.StatusAccepted, model.RoleClient}:       true,
+		{model.StatusSent, model.StatusRejected, model.RoleClient}:       true,
+		{model.StatusSent, model.StatusWithdrawn, model.RoleFreelancer}:  true,
+		{model.StatusSent, model.StatusWithdrawn, model.RoleSystem}:      true,
+		{model.StatusSent, model.StatusExpired, model.RoleSystem}:        true,
+		{model.StatusAccepted, model.StatusContracted, model.RoleSystem}: true,
+	}
+	roles := []string{model.RoleFreelancer, model.RoleClient, model.RoleAdmin, model.RoleSystem}
+
+	for from := range knownStatuses {
+		for to := range knownStatuses {
+			if from == to {
+				continue
+			}
+			for _, role := range roles {
+				err := checkStatusTransition(from, to, role)
+				if want := allowed[move{from, to, role}]; (err == nil) != want {
+					t.Errorf("%s moving %s -> %s: error = %v, want allowed %v", role, from, to, err, want)
+				}
+			}
+		}
+	}
+
+	rejected := []struct {
+		name           string
+		from, to, role string
+		code           codes.Code
+	}{
+		{"client withdraws", model.StatusSent, model.StatusWithdrawn, model.RoleClient, codes.PermissionDenied},
+		{"freelancer accepts own proposal", model.StatusSent, model.StatusAccepted, model.RoleFreelancer, codes.PermissionDenied},
+		{"client expires", model.StatusSent, model.StatusExpired, model.RoleClient, codes.PermissionDenied},
+		{"draft accepted before it is sent", model.StatusDraft, model.StatusAccepted, model.RoleClient, codes.FailedPrecondition},
+		{"accepted reopened", model.StatusAccepted, model.StatusSent, model.RoleFreelancer, codes.FailedPrecondition},
+		{"out of rejected", model.StatusRejected, model.StatusSent, model.RoleFreelancer, codes.FailedPrecondition},
+		{"out of withdrawn", model.StatusWithdrawn, model.StatusDraft, model.RoleSystem, codes.FailedPrecondition},
+		{"out of expired", model.StatusExpired, model.StatusSent, model.RoleSystem, codes.FailedPrecondition},
+		{"out of contracted", model.StatusContracted, model.StatusAccepted, model.RoleSystem, codes.FailedPrecondition},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStatusTransition(tt.from, tt.to, tt.role)
+			if status.Code(err) != tt.code {
+				t.Errorf("error = %v, want code %s", err, tt.code)
+			}
+		})
+	}
+
+	for s := range knownStatuses {
+		if err := checkStatusTransition(s, s, model.RoleClient); err != nil {
+			t.Errorf("keeping status %s: %v", s, err)
+		}
+	}
+}
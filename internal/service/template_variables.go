@@ -0,0 +1,132 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/placeholder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const variableDateLayout = "2006-01-02"
+
+// checkTemplateVariables validates the variable definitions of a template and
+// that every placeholder it uses is declared. An empty type means string.
+func checkTemplateVariables(template *model.Template) error {
+	declared := make(map[string]bool, len(template.Variables))
+	for i := range template.Variables {
+		v := &template.Variables[i]
+		v.Name = strings.TrimSpace(v.Name)
+		if v.Type == "" {
+			v.Type = model.VariableString
+		}
+		if !placeholder.ValidName(v.Name) {
+			return status.Errorf(codes.InvalidArgument, "invalid variable name %q", v.Name)
+		}
+		if declared[v.Name] {
+			return status.Errorf(codes.InvalidArgument, "variable %q is declared twice", v.Name)
+		}
+		declared[v.Name] = true
+
+		switch v.Type {
+		case model.VariableString, model.VariableNumber, model.VariableDate:
+		default:
+			return status.Errorf(codes.InvalidArgument, "variable %q has unknown type %q", v.Name, v.Type)
+		}
+		if v.Default != "" {
+			if err := checkVariableValue(*v, v.Default); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range templatePlaceholders(template) {
+		if !declared[name] {
+			return status.Errorf(codes.InvalidArgument, "placeholder {{%s}} has no variable definition", name)
+		}
+	}
+	return nil
+}
+
+func checkVariableValue(v model.TemplateVariable, value string) error {
+	switch v.Type {
+	case model.VariableNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return status.Errorf(codes.InvalidArgument, "variable %q must be a number, got %q", v.Name, value)
+		}
+	case model.VariableDate:
+		if _, err := time.Parse(variableDateLayout, value); err != nil {
+			return status.Errorf(codes.InvalidArgument, "variable %q must be a date (YYYY-MM-DD), got %q", v.Name, value)
+		}
+	}
+	return nil
+}
+
+func templatePlaceholders(template *model.Template) []string {
+	text := []string{template.Title}
+	for _, sec := range template.Sections {
+		text = append(text, sec.Heading, sec.Body)
+	}
+	return placeholder.Names(strings.Join(text, "\n"))
+}
+
+// RenderTemplate substitutes values into the template title and sections.
+// Placeholders without a definition, as found in templates saved before
+// variables existed, are treated as required strings.
+func (s *ProposalService) RenderTemplate(template *model.Template, values map[string]string) (string, []model.Section, error) {
+	definitions := make(map[string]model.TemplateVariable, len(template.Variables))
+	for _, v := range template.Variables {
+		definitions[v.Name] = v
+	}
+	for _, name := range templatePlaceholders(template) {
+		if _, ok := definitions[name]; !ok {
+			definitions[name] = model.TemplateVariable{Name: name, Type: model.VariableString, Required: true}
+		}
+	}
+
+	for name := range values {
+		if _, ok := definitions[name]; !ok {
+			return "", nil, status.Errorf(codes.InvalidArgument, "template does not define variable %q", name)
+		}
+	}
+
+	resolved := make(map[string]string, len(definitions))
+	var missing []string
+	for name, v := range definitions {
+		value, ok := values[name]
+		if !ok || value == "" {
+			value = v.Default
+		}
+		if value == "" {
+			if v.Required {
+				missing = append(missing, name)
+			}
+			resolved[name] = ""
+			continue
+		}
+		if err := checkVariableValue(v, value); err != nil {
+			return "", nil, err
+		}
+		resolved[name] = value
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", nil, status.Errorf(codes.InvalidArgument, "missing template variables: %s", strings.Join(missing, ", "))
+	}
+
+	sections := make([]model.Section, 0, len(template.Sections))
+	for _, sec := range template.Sections {
+		sections = append(sections, model.Section{
+			ID:      sec.ID,
+			Heading: placeholder.Render(sec.Heading, resolved),
+			Body:    placeholder.Render(sec.Body, resolved),
+			Format:  sec.Format,
+			Order:   sec.Order,
+		})
+	}
+	return placeholder.Render(template.Title, resolved), sections, nil
+}
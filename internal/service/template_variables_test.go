@@ -0,0 +1,133 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCheckTemplateVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		variables []model.TemplateVariable
+		wantErr   string
+	}{
+		{"declared", "{{client}} by {{due}}", []model.TemplateVariable{{Name: "client"}, {Name: "due", Type: model.VariableDate}}, ""},
+		{"undeclared placeholder", "{{client}} pays {{rate}}", []model.TemplateVariable{{Name: "client"}}, "{{rate}} has no variable definition"},
+		{"invalid name", "", []model.TemplateVariable{{Name: "first name"}}, "invalid variable name"},
+		{"declared twice", "", []model.TemplateVariable{{Name: "a"}, {Name: " a "}}, "declared twice"},
+		{"unknown type", "", []model.TemplateVariable{{Name: "a", Type: "money"}}, "unknown type"},
+		{"bad number default", "", []model.TemplateVariable{{Name: "rate", Type: model.VariableNumber, Default: "12 USD"}}, "must be a number"},
+		{"bad date default", "", []model.TemplateVariable{{Name: "due", Type: model.VariableDate, Default: "01/02/2026"}}, "must be a date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &model.Template{
+				Title:     "Proposal",
+				Sections:  []model.Section{{Heading: "Scope", Body: tt.body}},
+				Variables: tt.variables,
+			}
+			err := checkTemplateVariables(template)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkTemplateVariables: %v", err)
+				}
+				return
+			}
+			wantCode(t, err, codes.InvalidArgument)
+			if err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckTemplateVariablesDefaultsToString(t *testing.T) {
+	template := &model.Template{Variables: []model.TemplateVariable{{Name: " client "}}}
+	if err := checkTemplateVariables(template); err != nil {
+		t.Fatalf("checkTemplateVariables: %v", err)
+	}
+	if v := template.Variables[0]; v.Name != "client" || v.Type != model.VariableString {
+		t.Errorf("variable = %+v, want a trimmed string variable", v)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	template := &model.Template{
+		Title: "{{client}} website",
+		Sections: []model.Section{
+			{ID: "s1", Heading: "Price", Body: "{{rate}} per hour from {{start}}, {{legacy}}"},
+		},
+		Variables: []model.TemplateVariable{
+			{Name: "client", Type: model.VariableString, Required: true},
+			{Name: "rate", Type: model.VariableNumber, Default: "90"},
+			{Name: "start", Type: model.VariableDate},
+		},
+	}
+	s := NewProposalService(newMemRepository(), nil, AttachmentLimits{}, 50)
+
+	tests := []struct {
+		name      string
+		values    map[string]string
+		wantTitle string
+		wantBody  string
+		wantErr   string
+	}{
+		{
+			name:      "values and defaults",
+			values:    map[string]string{"client": "Acme", "start": "2026-11-02", "legacy": "{{client}}"},
+			wantTitle: "Acme website",
+			wantBody:  "90 per hour from 2026-11-02, {{client}}",
+		},
+		{
+			name:      "optional variable left empty",
+			values:    map[string]string{"client": "Acme", "rate": "120.5", "legacy": "x"},
+			wantTitle: "Acme website",
+			wantBody:  "120.5 per hour from , x",
+		},
+		{
+			name:    "missing required and undeclared placeholder",
+			values:  map[string]string{"start": "2026-11-02"},
+			wantErr: "missing template variables: client, legacy",
+		},
+		{
+			name:    "unknown variable",
+			values:  map[string]string{"client": "Acme", "legacy": "x", "discount": "5"},
+			wantErr: `does not define variable "discount"`,
+		},
+		{
+			name:    "number that does not parse",
+			values:  map[string]string{"client": "Acme", "legacy": "x", "rate": "ninety"},
+			wantErr: `"rate" must be a number`,
+		},
+		{
+			name:    "date that does not parse",
+			values:  map[string]string{"client": "Acme", "legacy": "x", "start": "2026-02-30"},
+			wantErr: `"start" must be a date`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, sections, err := s.RenderTemplate(template, tt.values)
+			if tt.wantErr != "" {
+				wantCode(t, err, codes.InvalidArgument)
+				if err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error %q does not mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderTemplate: %v", err)
+			}
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+			if len(sections) != 1 || sections[0].ID != "s1" || sections[0].Heading != "Price" || sections[0].Body != tt.wantBody {
+				t.Errorf("sections = %+v, want body %q", sections, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The handlers below react to events from the rest of FreelanceX. Each is
+// idempotent so a redelivered event is harmless, and returns an error only
+// when the change could not be stored, so the consumer retries before
+// committing the offset.
+
+var systemActor = model.Actor{Role: model.RoleSystem}
+
+// IsRejection reports whether err is the service refusing a change, such as
+// an unknown proposal or a disallowed status transition, rather than failing
+// to store it. Retrying a rejected upstream event cannot succeed.
+func IsRejection(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition, codes.PermissionDenied:
+		return true
+	}
+	return false
+}
+
+// OnJobClosed withdraws the proposals still open for a job that is no longer
+// hiring.
+func (s *ProposalService) OnJobClosed(ctx context.Context, jobID string) error {
+	open, err := s.repo.GetOpenProposalsForJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	for _, p := range open {
+		if err := s.setStatus(ctx, p, model.StatusWithdrawn, ""); err != nil {
+			return fmt.Errorf("failed to withdraw proposal %s for closed job %s: %w", p.ID.Hex(), jobID, err)
+		}
+	}
+	return nil
+}
+
+// OnUserDeleted withdraws the deleted user's open proposals and archives all
+// of their proposals with the user's id anonymized.
+func (s *ProposalService) OnUserDeleted(ctx context.Context, userID string) error {
+	proposals, err := s.repo.GetActiveProposalsForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, p := range proposals {
+		if p.Status == model.StatusDraft || p.Status == model.StatusSent {
+			if err := s.setStatus(ctx, p, model.StatusWithdrawn, ""); err != nil {
+				return fmt.Errorf("failed to withdraw proposal %s of deleted user: %w", p.ID.Hex(), err)
+			}
+			if p, err = s.repo.GetProposalByID(ctx, p.ID.Hex()); err != nil {
+				return err
+			}
+		}
+		event := model.OutboxEvent{EventType: EventProposalArchived, Actor: systemActor}
+		if _, err := s.repo.ArchiveProposal(ctx, p, userID, event); err != nil {
+			return fmt.Errorf("failed to archive proposal %s of deleted user: %w", p.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// OnContractSigned marks an accepted proposal as contracted.
+func (s *ProposalService) OnContractSigned(ctx context.Context, proposalID, contractID string) error {
+	p, err := s.loadProposal(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+	if p.Status == model.StatusContracted {
+		return nil
+	}
+	if p.Status != model.StatusAccepted {
+		return status.Errorf(codes.FailedPrecondition, "contract %s signed for proposal %s in status %s", contractID, proposalID, p.Status)
+	}
+	return s.setStatus(ctx, p, model.StatusContracted, contractID)
+}
+
+// setStatus moves p to newStatus as the system, pinned to the version read.
+func (s *ProposalService) setStatus(ctx context.Context, p *model.Proposal, newStatus, contractID string) error {
+	fields := []string{model.FieldStatus}
+	if contractID != "" {
+		fields = append(fields, model.FieldContractID)
+	}
+	_, err := s.UpdateProposal(ctx, p.ID.Hex(), model.Proposal{
+		Status:     newStatus,
+		ContractID: contractID,
+		Version:    p.Version,
+	}, fields, systemActor)
+	return err
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/config"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/model"
+	"github.com/Prototype-1/freelanceX_proposal_service/kafka"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+)
+
+// consumeUpstream feeds events through a consumer on an in-memory broker into
+// s, the way main wires the real topics.
+func consumeUpstream(t *testing.T, s *ProposalService) *kafka.MemoryBroker {
+	t.Helper()
+	cfg := &config.Config{
+		KafkaUserDeletedTopic:    "user.deleted",
+		KafkaJobClosedTopic:      "job.closed",
+		KafkaContractSignedTopic: "contract.signed",
+	}
+	broker := kafka.NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- kafka.NewConsumer(broker, kafka.UpstreamHandlers(cfg, s, IsRejection), 3).Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	})
+	return broker
+}
+
+func waitCommitted(t *testing.T, broker *kafka.MemoryBroker, topic string, offset int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for broker.Committed(topic) < offset {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for offset %d of %s to be committed; at %d", offset, topic, broker.Committed(topic))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestJobClosedWithdrawsOpenProposals(t *testing.T) {
+	repo := newMemRepository()
+	draft := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "f1", JobID: "job-1", Status: model.StatusDraft})
+	sent := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "f2", JobID: "job-1", Status: model.StatusSent})
+	accepted := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "f3", JobID: "job-1", Status: model.StatusAccepted})
+	otherJob := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "f1", JobID: "job-2", Status: model.StatusSent})
+
+	broker := consumeUpstream(t, NewProposalService(repo, nil, AttachmentLimits{}, 50))
+	broker.Produce("job.closed", nil, []byte(`{"job_id": "job-1"}`))
+	waitCommitted(t, broker, "job.closed", 1)
+
+	want := map[string]string{
+		draft:    model.StatusWithdrawn,
+		sent:     model.StatusWithdrawn,
+		accepted: model.StatusAccepted,
+		otherJob: model.StatusSent,
+	}
+	for id, status := range want {
+		if got := repo.get(id).Status; got != status {
+			t.Errorf("proposal %s: status %s, want %s", id, got, status)
+		}
+	}
+}
+
+func TestUserDeletedWithdrawsAndArchives(t *testing.T) {
+	repo := newMemRepository()
+	open := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "gone", Status: model.StatusSent})
+	decided := repo.add(model.Proposal{ClientID: "gone", FreelancerID: "f1", Status: model.StatusRejected})
+	unrelated := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "f1", Status: model.StatusSent})
+
+	s := NewProposalService(repo, nil, AttachmentLimits{}, 50)
+	broker := consumeUpstream(t, s)
+	broker.Produce("user.deleted", nil, []byte(`{"user_id": "gone"}`))
+	waitCommitted(t, broker, "user.deleted", 1)
+
+	if p := repo.get(open); p.Status != model.StatusWithdrawn || p.ArchivedAt == nil || p.FreelancerID != model.DeletedUserID {
+		t.Errorf("open proposal = status %s, archived %v, freelancer %s; want withdrawn, archived and anonymized", p.Status, p.ArchivedAt, p.FreelancerID)
+	}
+	if p := repo.get(decided); p.Status != model.StatusRejected || p.ArchivedAt == nil || p.ClientID != model.DeletedUserID {
+		t.Errorf("decided proposal = status %s, archived %v, client %s; want rejected, archived and anonymized", p.Status, p.ArchivedAt, p.ClientID)
+	}
+	if p := repo.get(unrelated); p.Status != model.StatusSent || p.ArchivedAt != nil {
+		t.Errorf("unrelated proposal changed: status %s, archived %v", p.Status, p.ArchivedAt)
+	}
+
+	_, err := s.GetProposalByID(context.Background(), open, model.Actor{Role: model.RoleAdmin})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestContractSignedMarksProposalContracted(t *testing.T) {
+	repo := newMemRepository()
+	accepted := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "f1", Status: model.StatusAccepted})
+	sent := repo.add(model.Proposal{ClientID: "c1", FreelancerID: "f1", Status: model.StatusSent})
+
+	broker := consumeUpstream(t, NewProposalService(repo, nil, AttachmentLimits{}, 50))
+	// Events for unknown, malformed or undecided proposals can never apply
+	// and must not hold back the ones behind them.
+	broker.Produce("contract.signed", nil, []byte(`{"proposal_id": "`+primitive.NewObjectID().Hex()+`", "contract_id": "k0"}`))
+	broker.Produce("contract.signed", nil, []byte(`{"proposal_id": "not-an-id", "contract_id": "k1"}`))
+	broker.Produce("contract.signed", nil, []byte(`{"proposal_id": "`+sent+`", "contract_id": "k2"}`))
+	broker.Produce("contract.signed", nil, []byte(`{"proposal_id": "`+accepted+`", "contract_id": "k3"}`))
+	broker.Produce("contract.signed", nil, []byte(`{"proposal_id": "`+accepted+`", "contract_id": "k3"}`))
+	waitCommitted(t, broker, "contract.signed", 5)
+
+	p := repo.get(accepted)
+	if p.Status != model.StatusContracted || p.ContractID != "k3" {
+		t.Errorf("accepted proposal = status %s, contract %q; want contracted with k3", p.Status, p.ContractID)
+	}
+	if p.Version != 2 {
+		t.Errorf("redelivered event changed the proposal again: version %d, want 2", p.Version)
+	}
+	if p := repo.get(sent); p.Status != model.StatusSent || p.ContractID != "" {
+		t.Errorf("sent proposal = status %s, contract %q; want it unchanged", p.Status, p.ContractID)
+	}
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFSStore keeps blobs in a MongoDB GridFS bucket, using the blob id as
+// the GridFS file id.
+type GridFSStore struct {
+	bucket *gridfs.Bucket
+}
+
+func NewGridFSStore(db *mongo.Database, bucketName string) (*GridFSStore, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName(bucketName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GridFS bucket %s: %w", bucketName, err)
+	}
+	return &GridFSStore{bucket: bucket}, nil
+}
+
+func (s *GridFSStore) Put(ctx context.Context, id string, r io.Reader) (int64, error) {
+	upload, err := s.bucket.OpenUploadStreamWithID(id, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open upload stream: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := upload.SetWriteDeadline(deadline); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := copyContext(ctx, upload, r)
+	if err != nil {
+		if abortErr := upload.Abort(); abortErr != nil {
+			return n, fmt.Errorf("%w (aborting upload: %v)", err, abortErr)
+		}
+		return n, err
+	}
+	if err := upload.Close(); err != nil {
+		return n, fmt.Errorf("failed to finish upload: %w", err)
+	}
+	return n, nil
+}
+
+func (s *GridFSStore) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	download, err := s.bucket.OpenDownloadStream(id)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open download stream: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := download.SetReadDeadline(deadline); err != nil {
+			download.Close()
+			return nil, err
+		}
+	}
+	return download, nil
+}
+
+func (s *GridFSStore) Delete(ctx context.Context, id string) error {
+	if err := s.bucket.DeleteContext(ctx, id); err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete blob %s: %w", id, err)
+	}
+	return nil
+}
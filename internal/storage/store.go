@@ -0,0 +1,45 @@
+// Package storage keeps attachment bytes out of the proposal documents.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no blob is stored under an id.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores opaque blobs under caller-chosen ids.
+type BlobStore interface {
+	// Put stores everything read from r under id and returns the number of
+	// bytes written. A failed Put leaves nothing behind.
+	Put(ctx context.Context, id string, r io.Reader) (int64, error)
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// copyContext is io.Copy that stops between chunks once ctx is done.
+func copyContext(ctx context.Context, dst io.Writer, src io.Reader) (int64, error) {
+	buf := make([]byte, 32*1024)
+	var written int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			w, err := dst.Write(buf[:n])
+			written += int64(w)
+			if err != nil {
+				return written, err
+			}
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, readErr
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package kafka
+
+import (
+	"github.com/Prototype-1/freelanceX_proposal_service/config"
+	"github.com/segmentio/kafka-go"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// Reader is the part of kafka.Reader the consumer relies on, so an in-memory
+// broker (MemoryBroker) can stand in for Kafka in tests.
+type Reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// MessageHandler applies one message. Returning an error leaves the offset
+// uncommitted and the message is retried, unless the error is Permanent.
+type MessageHandler func(ctx context.Context, msg kafka.Message) error
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error that retrying cannot fix, such as a malformed
+// payload. The consumer logs it and moves past the message.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+const (
+	minRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff = time.Minute
+)
+
+// Consumer dispatches messages to a handler per topic and commits each offset
+// only once its handler has succeeded. A message that still fails after
+// maxAttempts tries is logged and skipped, so one bad event cannot stall the
+// partition; maxAttempts <= 0 retries without limit.
+type Consumer struct {
+	reader      Reader
+	handlers    map[string]MessageHandler
+	maxAttempts int
+}
+
+func NewConsumer(reader Reader, handlers map[string]MessageHandler, maxAttempts int) *Consumer {
+	return &Consumer{reader: reader, handlers: handlers, maxAttempts: maxAttempts}
+}
+
+// NewReader builds a consumer-group reader over the configured upstream topics.
+func NewReader(cfg *config.Config, topics []string) (*kafka.Reader, error) {
+	mechanism, err := parseSASL(cfg.KafkaSASLMechanism, cfg.KafkaSASLUsername, cfg.KafkaSASLPassword)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		SASLMechanism: mechanism,
+		TLS:           tlsConfig(cfg.KafkaTLS),
+	}
+
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     cfg.KafkaBrokers,
+		GroupID:     cfg.KafkaConsumerGroup,
+		GroupTopics: topics,
+		Dialer:      dialer,
+	}), nil
+}
+
+// Run consumes until ctx is cancelled.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if !c.handle(ctx, msg) {
+			return nil
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// handle retries msg with backoff until it is applied, known to be
+// unprocessable or out of attempts. It returns false if ctx ended first.
+func (c *Consumer) handle(ctx context.Context, msg kafka.Message) bool {
+	handler, ok := c.handlers[msg.Topic]
+	if !ok {
+		log.Printf("Kafka consumer: no handler for topic %s, skipping offset %d", msg.Topic, msg.Offset)
+		return true
+	}
+
+	backoff := minRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := handler(ctx, msg)
+		if err == nil {
+			return true
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			log.Printf("Kafka consumer: dropping %s message at offset %d: %v", msg.Topic, msg.Offset, err)
+			return true
+		}
+		if c.maxAttempts > 0 && attempt >= c.maxAttempts {
+			log.Printf("Kafka consumer: giving up on %s message at offset %d after %d attempts: %v", msg.Topic, msg.Offset, attempt, err)
+			return true
+		}
+
+		log.Printf("Kafka consumer: handling %s message at offset %d failed, retrying in %s: %v", msg.Topic, msg.Offset, backoff, err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Prototype-1/freelanceX_proposal_service/config"
+	"github.com/segmentio/kafka-go"
+)
+
+// runConsumer consumes from broker until the test ends.
+func runConsumer(t *testing.T, broker *MemoryBroker, handlers map[string]MessageHandler, maxAttempts int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewConsumer(broker, handlers, maxAttempts).Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	})
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestConsumerCommitsOnlyAfterHandlerSucceeds(t *testing.T) {
+	broker := NewMemoryBroker()
+	failing := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var calls int
+
+	handlers := map[string]MessageHandler{
+		"jobs": func(ctx context.Context, msg kafka.Message) error {
+			mu.Lock()
+			calls++
+			first := calls == 1
+			mu.Unlock()
+			if first {
+				close(failing)
+				<-release
+				return errors.New("database unavailable")
+			}
+			return nil
+		},
+	}
+	runConsumer(t, broker, handlers, 0)
+	broker.Produce("jobs", nil, []byte(`{}`))
+
+	<-failing
+	if got := broker.Committed("jobs"); got != 0 {
+		t.Fatalf("committed offset %d while the handler was failing", got)
+	}
+	close(release)
+
+	waitFor(t, "the retried message to be committed", func() bool { return broker.Committed("jobs") == 1 })
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
+
+func TestConsumerDropsPermanentErrors(t *testing.T) {
+	broker := NewMemoryBroker()
+	var mu sync.Mutex
+	var handled []string
+
+	handlers := map[string]MessageHandler{
+		"jobs": func(ctx context.Context, msg kafka.Message) error {
+			mu.Lock()
+			handled = append(handled, string(msg.Value))
+			mu.Unlock()
+			if string(msg.Value) == "bad" {
+				return Permanent(errors.New("malformed"))
+			}
+			return nil
+		},
+	}
+	runConsumer(t, broker, handlers, 0)
+	broker.Produce("jobs", nil, []byte("bad"))
+	broker.Produce("jobs", nil, []byte("good"))
+
+	waitFor(t, "both messages to be committed", func() bool { return broker.Committed("jobs") == 2 })
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handled) != 2 || handled[0] != "bad" || handled[1] != "good" {
+		t.Errorf("handled %q, want each message once in order", handled)
+	}
+}
+
+func TestConsumerGivesUpAfterMaxAttempts(t *testing.T) {
+	broker := NewMemoryBroker()
+	var mu sync.Mutex
+	attempts := map[string]int{}
+
+	handlers := map[string]MessageHandler{
+		"jobs": func(ctx context.Context, msg kafka.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts[string(msg.Value)]++
+			if string(msg.Value) == "stuck" {
+				return errors.New("still failing")
+			}
+			return nil
+		},
+	}
+	runConsumer(t, broker, handlers, 2)
+	broker.Produce("jobs", nil, []byte("stuck"))
+	broker.Produce("jobs", nil, []byte("next"))
+
+	waitFor(t, "the consumer to move past the stuck message", func() bool { return broker.Committed("jobs") == 2 })
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts["stuck"] != 2 || attempts["next"] != 1 {
+		t.Errorf("attempts = %v, want stuck tried twice and next once", attempts)
+	}
+}
+
+// recordingHandler records the upstream calls it receives and fails those
+// for proposals it does not know.
+type recordingHandler struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+var errProposalNotFound = errors.New("proposal not found")
+
+func (h *recordingHandler) record(call string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, call)
+}
+
+func (h *recordingHandler) Calls() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.calls...)
+}
+
+func (h *recordingHandler) OnUserDeleted(ctx context.Context, userID string) error {
+	h.record("user-deleted " + userID)
+	return nil
+}
+
+func (h *recordingHandler) OnJobClosed(ctx context.Context, jobID string) error {
+	h.record("job-closed " + jobID)
+	return nil
+}
+
+func (h *recordingHandler) OnContractSigned(ctx context.Context, proposalID, contractID string) error {
+	h.record("contract-signed " + proposalID + " " + contractID)
+	if proposalID == "missing" {
+		return errProposalNotFound
+	}
+	return nil
+}
+
+func TestUpstreamHandlersDispatchEvents(t *testing.T) {
+	cfg := &config.Config{
+		KafkaUserDeletedTopic:    "user.deleted",
+		KafkaJobClosedTopic:      "job.closed",
+		KafkaContractSignedTopic: "contract.signed",
+	}
+	handler := &recordingHandler{}
+	broker := NewMemoryBroker()
+	isPermanent := func(err error) bool { return errors.Is(err, errProposalNotFound) }
+	runConsumer(t, broker, UpstreamHandlers(cfg, handler, isPermanent), 0)
+
+	broker.Produce("job.closed", nil, []byte(`{"job_id": "job-1"}`))
+	broker.Produce("user.deleted", nil, []byte(`{"user_id": "user-1"}`))
+	broker.Produce("contract.signed", nil, []byte(`{"proposal_id": "missing", "contract_id": "c-0"}`))
+	broker.Produce("contract.signed", nil, []byte(`not json`))
+	broker.Produce("contract.signed", nil, []byte(`{"contract_id": "c-1"}`))
+	broker.Produce("contract.signed", nil, []byte(`{"proposal_id": "p-1", "contract_id": "c-2"}`))
+
+	waitFor(t, "all events to be committed", func() bool {
+		return broker.Committed("job.closed") == 1 && broker.Committed("user.deleted") == 1 && broker.Committed("contract.signed") == 4
+	})
+	want := []string{"job-closed job-1", "user-deleted user-1", "contract-signed missing c-0", "contract-signed p-1 c-2"}
+	got := handler.Calls()
+	if len(got) != len(want) {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/Prototype-1/freelanceX_proposal_service/proto"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const HeaderContentType = "content-type"
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Encoding selects the wire format of published events.
+type Encoding string
+
+const (
+	EncodingJSON     Encoding = "json"
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+func parseEncoding(value string) (Encoding, error) {
+	switch Encoding(strings.ToLower(value)) {
+	case "", EncodingJSON:
+		return EncodingJSON, nil
+	case EncodingProtobuf:
+		return EncodingProtobuf, nil
+	}
+	return "", fmt.Errorf("unsupported KAFKA_EVENT_ENCODING %q", value)
+}
+
+// encode serializes event and returns the content type to advertise with it.
+func (enc Encoding) encode(event ProposalEvent) ([]byte, string, error) {
+	if enc == EncodingProtobuf {
+		data, err := proto.Marshal(event.toProto())
+		return data, ContentTypeProtobuf, err
+	}
+	data, err := json.Marshal(event)
+	return data, ContentTypeJSON, err
+}
+
+// message builds the Kafka message for event: keyed by proposal id, with the
+// event type, schema version and content type as headers.
+func (enc Encoding) message(event ProposalEvent) (kafka.Message, error) {
+	data, contentType, err := enc.encode(event)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	return kafka.Message{
+		Key:   []byte(event.ProposalID),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: HeaderEventType, Value: []byte(event.EventType)},
+			{Key: HeaderSchemaVersion, Value: []byte(strconv.Itoa(event.SchemaVersion))},
+			{Key: HeaderContentType, Value: []byte(contentType)},
+		},
+	}, nil
+}
+
+func (e ProposalEvent) toProto() *pb.ProposalEvent {
+	return &pb.ProposalEvent{
+		EventId:       e.EventID,
+		EventType:     e.EventType,
+		SchemaVersion: int32(e.SchemaVersion),
+		OccurredAt:    timestamppb.New(e.OccurredAt),
+		ProposalId:    e.ProposalID,
+		Actor: &pb.ProposalEventActor{
+			UserId: e.Actor.UserID,
+			Role:   e.Actor.Role,
+		},
+		Before: e.Before.toProto(),
+		After:  e.After.toProto(),
+	}
+}
+
+func (s *ProposalSnapshot) toProto() *pb.ProposalSnapshot {
+	if s == nil {
+		return nil
+	}
+	sections := make([]*pb.Section, 0, len(s.Sections))
+	for _, sec := range s.Sections {
+		sections = append(sections, &pb.Section{Id: sec.ID, Heading: sec.Heading, Body: sec.Body, Format: sec.Format, Order: int32(sec.Order)})
+	}
+	var archivedAt *timestamppb.Timestamp
+	if s.ArchivedAt != nil {
+		archivedAt = timestamppb.New(*s.ArchivedAt)
+	}
+	return &pb.ProposalSnapshot{
+		ProposalId:      s.ProposalID,
+		ClientId:        s.ClientID,
+		FreelancerId:    s.FreelancerID,
+		TemplateId:      s.TemplateID,
+		TemplateVersion: int32(s.TemplateVersion),
+		Title:           s.Title,
+		Content:         s.Content,
+		ContentFormat:   s.ContentFormat,
+		Sections:        sections,
+		Status:          s.Status,
+		Version:         int32(s.Version),
+		Deadline:        timestamppb.New(s.Deadline),
+		CreatedAt:       timestamppb.New(s.CreatedAt),
+		UpdatedAt:       timestamppb.New(s.UpdatedAt),
+		JobId:           s.JobID,
+		ContractId:      s.ContractID,
+		ArchivedAt:      archivedAt,
+		Pricing:         s.Pricing.toProto(),
+		Milestones:      milestonesToProto(s.Milestones),
+		Attachments:     attachmentsToProto(s.Attachments),
+	}
+}
+
+func (s *PricingSnapshot) toProto() *pb.Pricing {
+	if s == nil {
+		return nil
+	}
+	pricing := &pb.Pricing{
+		Currency:        s.Currency,
+		Type:            s.Type,
+		FixedPriceCents: s.FixedPriceCents,
+		HourlyRateCents: s.HourlyRateCents,
+		EstimatedHours:  s.EstimatedHours,
+		Discounts:       adjustmentsToProto(s.Discounts),
+		Taxes:           adjustmentsToProto(s.Taxes),
+		SubtotalCents:   s.SubtotalCents,
+		DiscountCents:   s.DiscountCents,
+		TaxCents:        s.TaxCents,
+		TotalCents:      s.TotalCents,
+	}
+	for _, item := range s.LineItems {
+		pricing.LineItems = append(pricing.LineItems, &pb.LineItem{
+			Description:    item.Description,
+			Quantity:       item.Quantity,
+			UnitPriceCents: item.UnitPriceCents,
+			AmountCents:    item.AmountCents,
+		})
+	}
+	return pricing
+}
+
+func adjustmentsToProto(adjustments []AdjustmentSnapshot) []*pb.PriceAdjustment {
+	var result []*pb.PriceAdjustment
+	for _, adj := range adjustments {
+		result = append(result, &pb.PriceAdjustment{
+			Name:         adj.Name,
+			PercentBps:   adj.PercentBps,
+			AmountCents:  adj.AmountCents,
+			AppliedCents: adj.AppliedCents,
+		})
+	}
+	return result
+}
+
+func milestonesToProto(milestones []MilestoneSnapshot) []*pb.Milestone {
+	var result []*pb.Milestone
+	for _, m := range milestones {
+		result = append(result, &pb.Milestone{
+			Id:           m.ID,
+			Title:        m.Title,
+			Description:  m.Description,
+			DueDate:      timestamppb.New(m.DueDate),
+			AmountCents:  m.AmountCents,
+			Deliverables: m.Deliverables,
+		})
+	}
+	return result
+}
+
+func attachmentsToProto(attachments []AttachmentSnapshot) []*pb.Attachment {
+	var result []*pb.Attachment
+	for _, a := range attachments {
+		result = append(result, &pb.Attachment{
+			Id:             a.ID,
+			Name:           a.Name,
+			SizeBytes:      a.Size,
+			ContentType:    a.ContentType,
+			ChecksumSha256: a.Checksum,
+			UploadedBy:     a.UploadedBy,
+			UploadedAt:     timestamppb.New(a.UploadedAt),
+		})
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MemoryPublisher records events in memory instead of sending them to Kafka,
+// along with the JSON-encoded messages Producer would have written.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	events   []ProposalEvent
+	messages []kafka.Message
+	closed   bool
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (m *MemoryPublisher) Publish(ctx context.Context, event ProposalEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return errors.New("publisher is closed")
+	}
+	msg, err := EncodingJSON.message(event)
+	if err != nil {
+		return err
+	}
+	m.events = append(m.events, event)
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func (m *MemoryPublisher) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+// Events returns a copy of everything published so far.
+func (m *MemoryPublisher) Events() []ProposalEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ProposalEvent(nil), m.events...)
+}
+
+// Messages returns a copy of the messages published so far.
+func (m *MemoryPublisher) Messages() []kafka.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]kafka.Message(nil), m.messages...)
+}
+
+// MemoryBroker is an in-memory stand-in for Kafka on the consuming side. It
+// implements Reader, delivering produced messages in order and recording
+// which offsets were committed.
+type MemoryBroker struct {
+	mu        sync.Mutex
+	queue     []kafka.Message
+	ready     chan struct{}
+	offsets   map[string]int64
+	committed map[string]int64
+	closed    bool
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		ready:     make(chan struct{}, 1),
+		offsets:   make(map[string]int64),
+		committed: make(map[string]int64),
+	}
+}
+
+// Produce appends a message to topic.
+func (b *MemoryBroker) Produce(topic string, key, value []byte) {
+	b.mu.Lock()
+	msg := kafka.Message{Topic: topic, Offset: b.offsets[topic], Key: key, Value: value}
+	b.offsets[topic]++
+	b.queue = append(b.queue, msg)
+	b.mu.Unlock()
+
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (b *MemoryBroker) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return kafka.Message{}, errors.New("broker is closed")
+		}
+		if len(b.queue) > 0 {
+			msg := b.queue[0]
+			b.queue = b.queue[1:]
+			b.mu.Unlock()
+			return msg, nil
+		}
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		case <-b.ready:
+		}
+	}
+}
+
+func (b *MemoryBroker) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, msg := range msgs {
+		if next := msg.Offset + 1; next > b.committed[msg.Topic] {
+			b.committed[msg.Topic] = next
+		}
+	}
+	return nil
+}
+
+// Committed returns the next offset to be read from topic after the last
+// commit, i.e. the number of messages acknowledged so far.
+func (b *MemoryBroker) Committed(topic string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.committed[topic]
+}
+
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
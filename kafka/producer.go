@@ -1,45 +1,244 @@
 package kafka
 
 import (
-	"github.com/segmentio/kafka-go"
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"fmt"
+	"github.com/Prototype-1/freelanceX_proposal_service/config"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
 	"log"
+	"strings"
+	"time"
 )
 
+// SchemaVersion identifies the layout of ProposalEvent. Version 1 was the
+// original flat, unversioned payload.
+const SchemaVersion = 2
+
+const (
+	HeaderEventType     = "event_type"
+	HeaderSchemaVersion = "schema_version"
+)
+
+// ProposalEvent is the envelope published for every proposal change.
+// Messages are keyed by proposal id so each proposal's events stay ordered.
 type ProposalEvent struct {
-	ProposalID   string `json:"proposal_id"`
-	ClientID     string `json:"client_id"`
-	FreelancerID string `json:"freelancer_id"`
-	Title        string `json:"title"`
-	EventType    string `json:"event_type"`
-	Status       string `json:"status"`     
-}
-
-func ProduceProposalEvent(broker, topic string, event ProposalEvent) error {
-	w := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:  []string{broker},
-		Topic:    topic,
-		Balancer: &kafka.LeastBytes{},
-	})
-	defer w.Close()
-
-	data, err := json.Marshal(event)
+	EventID       string            `json:"event_id"`
+	EventType     string            `json:"event_type"`
+	SchemaVersion int               `json:"schema_version"`
+	OccurredAt    time.Time         `json:"occurred_at"`
+	ProposalID    string            `json:"proposal_id"`
+	Actor         Actor             `json:"actor"`
+	Before        *ProposalSnapshot `json:"before,omitempty"`
+	After         *ProposalSnapshot `json:"after,omitempty"`
+}
+
+type Actor struct {
+	UserID string `json:"user_id,omitempty"`
+	Role   string `json:"role"`
+}
+
+type ProposalSnapshot struct {
+	ProposalID      string               `json:"proposal_id"`
+	ClientID        string               `json:"client_id"`
+	FreelancerID    string               `json:"freelancer_id"`
+	TemplateID      string               `json:"template_id,omitempty"`
+	TemplateVersion int                  `json:"template_version,omitempty"`
+	Title           string               `json:"title"`
+	Content         string               `json:"content"`
+	ContentFormat   string               `json:"content_format,omitempty"`
+	Sections        []SectionSnapshot    `json:"sections,omitempty"`
+	Status          string               `json:"status"`
+	Version         int                  `json:"version"`
+	Deadline        time.Time            `json:"deadline"`
+	JobID           string               `json:"job_id,omitempty"`
+	ContractID      string               `json:"contract_id,omitempty"`
+	ArchivedAt      *time.Time           `json:"archived_at,omitempty"`
+	Pricing         *PricingSnapshot     `json:"pricing,omitempty"`
+	Milestones      []MilestoneSnapshot  `json:"milestones,omitempty"`
+	Attachments     []AttachmentSnapshot `json:"attachments,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
+
+// PricingSnapshot mirrors model.Pricing; amounts are in minor units and
+// percentages in basis points.
+type PricingSnapshot struct {
+	Currency        string               `json:"currency"`
+	Type            string               `json:"type"`
+	FixedPriceCents int64                `json:"fixed_price_cents,omitempty"`
+	HourlyRateCents int64                `json:"hourly_rate_cents,omitempty"`
+	EstimatedHours  float64              `json:"estimated_hours,omitempty"`
+	LineItems       []LineItemSnapshot   `json:"line_items,omitempty"`
+	Discounts       []AdjustmentSnapshot `json:"discounts,omitempty"`
+	Taxes           []AdjustmentSnapshot `json:"taxes,omitempty"`
+	SubtotalCents   int64                `json:"subtotal_cents"`
+	DiscountCents   int64                `json:"discount_cents"`
+	TaxCents        int64                `json:"tax_cents"`
+	TotalCents      int64                `json:"total_cents"`
+}
+
+type LineItemSnapshot struct {
+	Description    string `json:"description"`
+	Quantity       int64  `json:"quantity"`
+	UnitPriceCents int64  `json:"unit_price_cents"`
+	AmountCents    int64  `json:"amount_cents"`
+}
+
+type AdjustmentSnapshot struct {
+	Name         string `json:"name"`
+	PercentBps   int64  `json:"percent_bps,omitempty"`
+	AmountCents  int64  `json:"amount_cents,omitempty"`
+	AppliedCents int64  `json:"applied_cents"`
+}
+
+type MilestoneSnapshot struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description,omitempty"`
+	DueDate      time.Time `json:"due_date"`
+	AmountCents  int64     `json:"amount_cents"`
+	Deliverables []string  `json:"deliverables,omitempty"`
+}
+
+// AttachmentSnapshot carries attachment metadata; the content stays in the
+// blob store.
+type AttachmentSnapshot struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	Checksum    string    `json:"checksum"`
+	UploadedBy  string    `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+type SectionSnapshot struct {
+	ID      string `json:"id,omitempty"`
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+	Format  string `json:"format,omitempty"`
+	Order   int    `json:"order,omitempty"`
+}
+
+// Publisher sends proposal events to the broker. Producer is the Kafka
+// implementation; MemoryPublisher stands in for it in tests.
+type Publisher interface {
+	Publish(ctx context.Context, event ProposalEvent) error
+	Close() error
+}
+
+// Producer holds one long-lived Kafka writer for the life of the service.
+type Producer struct {
+	writer   *kafka.Writer
+	encoding Encoding
+}
+
+func NewProducer(cfg *config.Config) (*Producer, error) {
+	acks, err := parseRequiredAcks(cfg.KafkaRequiredAcks)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	compression, err := parseCompression(cfg.KafkaCompression)
+	if err != nil {
+		return nil, err
+	}
+	encoding, err := parseEncoding(cfg.KafkaEventEncoding)
+	if err != nil {
+		return nil, err
+	}
+	mechanism, err := parseSASL(cfg.KafkaSASLMechanism, cfg.KafkaSASLUsername, cfg.KafkaSASLPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &kafka.Transport{SASL: mechanism, TLS: tlsConfig(cfg.KafkaTLS)}
 
-	err = w.WriteMessages(context.Background(),
-		kafka.Message{
-			Value: data,
+	return &Producer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.KafkaBrokers...),
+			Topic:        cfg.KafkaTopic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: acks,
+			BatchSize:    cfg.KafkaBatchSize,
+			BatchTimeout: cfg.KafkaBatchTimeout,
+			Compression:  compression,
+			Transport:    transport,
 		},
-	)
+		encoding: encoding,
+	}, nil
+}
+
+func (p *Producer) Publish(ctx context.Context, event ProposalEvent) error {
+	msg, err := p.encoding.message(event)
+	if err != nil {
+		return err
+	}
+
+	err = p.writer.WriteMessages(ctx, msg)
 
 	if err != nil {
 		log.Printf("Kafka write error: %v", err)
 		return err
 	}
 
-	log.Printf("Produced event to Kafka: %+v", event)
+	log.Printf("Produced %s event %s for proposal %s to Kafka", event.EventType, event.EventID, event.ProposalID)
 	return nil
 }
+
+// Close flushes buffered messages and releases broker connections.
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
+
+func tlsConfig(enabled bool) *tls.Config {
+	if !enabled {
+		return nil
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12}
+}
+
+func parseRequiredAcks(value string) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(value) {
+	case "", "all":
+		return kafka.RequireAll, nil
+	case "one":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	}
+	return 0, fmt.Errorf("unsupported KAFKA_REQUIRED_ACKS %q", value)
+}
+
+func parseCompression(value string) (kafka.Compression, error) {
+	switch strings.ToLower(value) {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	}
+	return 0, fmt.Errorf("unsupported KAFKA_COMPRESSION %q", value)
+}
+
+func parseSASL(mechanism, username, password string) (sasl.Mechanism, error) {
+	switch strings.ToLower(mechanism) {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	}
+	return nil, fmt.Errorf("unsupported KAFKA_SASL_MECHANISM %q", mechanism)
+}
@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"github.com/Prototype-1/freelanceX_proposal_service/config"
+	"github.com/segmentio/kafka-go"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// UpstreamHandler reacts to events published by other FreelanceX services.
+type UpstreamHandler interface {
+	OnUserDeleted(ctx context.Context, userID string) error
+	OnJobClosed(ctx context.Context, jobID string) error
+	OnContractSigned(ctx context.Context, proposalID, contractID string) error
+}
+
+type UserDeletedEvent struct {
+	UserID string `json:"user_id"`
+}
+
+type JobClosedEvent struct {
+	JobID string `json:"job_id"`
+}
+
+type ContractSignedEvent struct {
+	ContractID string `json:"contract_id"`
+	ProposalID string `json:"proposal_id"`
+}
+
+// UpstreamHandlers maps the configured upstream topics to h. Topics left
+// empty in the configuration are not consumed. isPermanent reports whether an
+// error from h is a rejection that retrying cannot fix; anything else, such as
+// a database outage or version conflict, is retried.
+func UpstreamHandlers(cfg *config.Config, h UpstreamHandler, isPermanent func(error) bool) map[string]MessageHandler {
+	handlers := make(map[string]MessageHandler)
+	classify := func(err error) error {
+		if err != nil && isPermanent(err) {
+			return Permanent(err)
+		}
+		return err
+	}
+
+	if cfg.KafkaUserDeletedTopic != "" {
+		handlers[cfg.KafkaUserDeletedTopic] = func(ctx context.Context, msg kafka.Message) error {
+			var event UserDeletedEvent
+			if err := decodeUpstream(msg, &event); err != nil {
+				return err
+			}
+			if event.UserID == "" {
+				return Permanent(errors.New("user deleted event has no user_id"))
+			}
+			return classify(h.OnUserDeleted(ctx, event.UserID))
+		}
+	}
+
+	if cfg.KafkaJobClosedTopic != "" {
+		handlers[cfg.KafkaJobClosedTopic] = func(ctx context.Context, msg kafka.Message) error {
+			var event JobClosedEvent
+			if err := decodeUpstream(msg, &event); err != nil {
+				return err
+			}
+			if event.JobID == "" {
+				return Permanent(errors.New("job closed event has no job_id"))
+			}
+			return classify(h.OnJobClosed(ctx, event.JobID))
+		}
+	}
+
+	if cfg.KafkaContractSignedTopic != "" {
+		handlers[cfg.KafkaContractSignedTopic] = func(ctx context.Context, msg kafka.Message) error {
+			var event ContractSignedEvent
+			if err := decodeUpstream(msg, &event); err != nil {
+				return err
+			}
+			if event.ProposalID == "" {
+				return Permanent(errors.New("contract signed event has no proposal_id"))
+			}
+			return classify(h.OnContractSigned(ctx, event.ProposalID, event.ContractID))
+		}
+	}
+
+	return handlers
+}
+
+func decodeUpstream(msg kafka.Message, v interface{}) error {
+	if err := json.Unmarshal(msg.Value, v); err != nil {
+		return Permanent(fmt.Errorf("malformed %s payload: %w", msg.Topic, err))
+	}
+	return nil
+}
@@ -4,12 +4,19 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 	"context"
 	"github.com/Prototype-1/freelanceX_proposal_service/config"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/auth"
 	"github.com/Prototype-1/freelanceX_proposal_service/internal/handler"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/outbox"
 	"github.com/Prototype-1/freelanceX_proposal_service/internal/repository"
 	"github.com/Prototype-1/freelanceX_proposal_service/internal/service"
+	"github.com/Prototype-1/freelanceX_proposal_service/internal/storage"
+	"github.com/Prototype-1/freelanceX_proposal_service/kafka"
 	"github.com/Prototype-1/freelanceX_proposal_service/proto"
 	"google.golang.org/grpc"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -25,8 +32,18 @@ func main() {
 	}
 	defer client.Disconnect(ctx)
 
-	proposalRepo := repository.NewProposalRepository(client)
-	proposalService := service.NewProposalService(proposalRepo)
+	proposalRepo := repository.NewProposalRepository(client, cfg.DatabaseName)
+	if err := proposalRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Failed to ensure indexes: %v", err)
+	}
+	blobs, err := newBlobStore(cfg, client)
+	if err != nil {
+		log.Fatalf("Failed to configure attachment store: %v", err)
+	}
+	proposalService := service.NewProposalService(proposalRepo, blobs, service.AttachmentLimits{
+		MaxBytes:     cfg.AttachmentMaxBytes,
+		AllowedTypes: cfg.AttachmentAllowedTypes,
+	}, cfg.ListMaxPageSize)
 	proposalHandler := handler.NewProposalHandler(proposalService)
 
 	go func() {
@@ -35,22 +52,81 @@ func main() {
 	
 		for range ticker.C {
 			log.Println("Checking and expiring proposals...")
-			if err := proposalRepo.ExpireProposals(ctx); err != nil {
+			if err := proposalService.ExpireProposals(ctx); err != nil {
 				log.Printf("Error expiring proposals: %v", err)
 			}
 		}
 	}()
 
+	producer, err := kafka.NewProducer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure Kafka producer: %v", err)
+	}
+	defer producer.Close()
+
+	bgCtx, stopBackground := context.WithCancel(ctx)
+	relay := outbox.NewRelay(proposalRepo, producer, proposalService.OnEventDelivered, 2*time.Second)
+	go relay.Run(bgCtx)
+
+	upstreamHandlers := kafka.UpstreamHandlers(cfg, proposalService, service.IsRejection)
+	if len(upstreamHandlers) > 0 {
+		topics := make([]string, 0, len(upstreamHandlers))
+		for topic := range upstreamHandlers {
+			topics = append(topics, topic)
+		}
+		reader, err := kafka.NewReader(cfg, topics)
+		if err != nil {
+			log.Fatalf("Failed to configure Kafka consumer: %v", err)
+		}
+		defer reader.Close()
+
+		consumer := kafka.NewConsumer(reader, upstreamHandlers, cfg.KafkaConsumerMaxAttempts)
+		go func() {
+			if err := consumer.Run(bgCtx); err != nil {
+				log.Printf("Kafka consumer stopped: %v", err)
+			}
+		}()
+	}
+
 	lis, err := net.Listen("tcp", cfg.ServerPort)
 	if err != nil {
 		log.Fatalf("Failed to listen on port %s: %v", cfg.ServerPort, err)
 	}
 
-	grpcServer := grpc.NewServer()
+	keys, err := auth.LoadKeySet(cfg.JWTSecret, cfg.JWTPublicKeyFile, cfg.JWKSFile)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	authenticator := auth.NewAuthenticator(keys, cfg.JWTIssuer, cfg.JWTAudience)
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+	)
 	proposal.RegisterProposalServiceServer(grpcServer, proposalHandler)
 
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		log.Println("Shutting down gRPC server...")
+		stopBackground()
+		grpcServer.GracefulStop()
+	}()
+
 	fmt.Printf("Starting gRPC server on port %s...\n", cfg.ServerPort)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("Failed to start gRPC server: %v", err)
 	}
 }
+
+func newBlobStore(cfg *config.Config, client *mongo.Client) (storage.BlobStore, error) {
+	switch cfg.AttachmentStore {
+	case "gridfs":
+		return storage.NewGridFSStore(client.Database(cfg.DatabaseName), "attachments")
+	case "local":
+		return storage.NewLocalStore(cfg.AttachmentDir)
+	default:
+		return nil, fmt.Errorf("unknown ATTACHMENT_STORE %q (want gridfs or local)", cfg.AttachmentStore)
+	}
+}
@@ -24,7 +24,27 @@ const (
 	ProposalService_UpdateProposal_FullMethodName            = "/proposal.ProposalService/UpdateProposal"
 	ProposalService_SaveTemplate_FullMethodName              = "/proposal.ProposalService/SaveTemplate"
 	ProposalService_GetTemplatesForFreelancer_FullMethodName = "/proposal.ProposalService/GetTemplatesForFreelancer"
+	ProposalService_GetTemplate_FullMethodName               = "/proposal.ProposalService/GetTemplate"
+	ProposalService_UpdateTemplate_FullMethodName            = "/proposal.ProposalService/UpdateTemplate"
+	ProposalService_DeleteTemplate_FullMethodName            = "/proposal.ProposalService/DeleteTemplate"
+	ProposalService_ListProposalsByTemplate_FullMethodName   = "/proposal.ProposalService/ListProposalsByTemplate"
+	ProposalService_GetTemplateStats_FullMethodName          = "/proposal.ProposalService/GetTemplateStats"
+	ProposalService_AddProposalSection_FullMethodName        = "/proposal.ProposalService/AddProposalSection"
+	ProposalService_UpdateProposalSection_FullMethodName     = "/proposal.ProposalService/UpdateProposalSection"
+	ProposalService_ReorderProposalSections_FullMethodName   = "/proposal.ProposalService/ReorderProposalSections"
+	ProposalService_RemoveProposalSection_FullMethodName     = "/proposal.ProposalService/RemoveProposalSection"
+	ProposalService_SetMilestones_FullMethodName             = "/proposal.ProposalService/SetMilestones"
+	ProposalService_UploadAttachment_FullMethodName          = "/proposal.ProposalService/UploadAttachment"
+	ProposalService_DownloadAttachment_FullMethodName        = "/proposal.ProposalService/DownloadAttachment"
+	ProposalService_RemoveAttachment_FullMethodName          = "/proposal.ProposalService/RemoveAttachment"
+	ProposalService_ExportProposal_FullMethodName            = "/proposal.ProposalService/ExportProposal"
+	ProposalService_GetBrandingProfile_FullMethodName        = "/proposal.ProposalService/GetBrandingProfile"
+	ProposalService_SetBrandingProfile_FullMethodName        = "/proposal.ProposalService/SetBrandingProfile"
 	ProposalService_ListProposals_FullMethodName             = "/proposal.ProposalService/ListProposals"
+	ProposalService_GetProposalRevisions_FullMethodName      = "/proposal.ProposalService/GetProposalRevisions"
+	ProposalService_GetProposalRevision_FullMethodName       = "/proposal.ProposalService/GetProposalRevision"
+	ProposalService_RestoreRevision_FullMethodName           = "/proposal.ProposalService/RestoreRevision"
+	ProposalService_DiffProposalVersions_FullMethodName      = "/proposal.ProposalService/DiffProposalVersions"
 )
 
 // ProposalServiceClient is the client API for ProposalService service.
@@ -36,7 +56,27 @@ type ProposalServiceClient interface {
 	UpdateProposal(ctx context.Context, in *UpdateProposalRequest, opts ...grpc.CallOption) (*UpdateProposalResponse, error)
 	SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*SaveTemplateResponse, error)
 	GetTemplatesForFreelancer(ctx context.Context, in *GetTemplatesRequest, opts ...grpc.CallOption) (*GetTemplatesResponse, error)
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*UpdateTemplateResponse, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	ListProposalsByTemplate(ctx context.Context, in *ListProposalsByTemplateRequest, opts ...grpc.CallOption) (*ListProposalsByTemplateResponse, error)
+	GetTemplateStats(ctx context.Context, in *GetTemplateStatsRequest, opts ...grpc.CallOption) (*GetTemplateStatsResponse, error)
+	AddProposalSection(ctx context.Context, in *AddProposalSectionRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error)
+	UpdateProposalSection(ctx context.Context, in *UpdateProposalSectionRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error)
+	ReorderProposalSections(ctx context.Context, in *ReorderProposalSectionsRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error)
+	RemoveProposalSection(ctx context.Context, in *RemoveProposalSectionRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error)
+	SetMilestones(ctx context.Context, in *SetMilestonesRequest, opts ...grpc.CallOption) (*SetMilestonesResponse, error)
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error)
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error)
+	RemoveAttachment(ctx context.Context, in *RemoveAttachmentRequest, opts ...grpc.CallOption) (*RemoveAttachmentResponse, error)
+	ExportProposal(ctx context.Context, in *ExportProposalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProposalResponse], error)
+	GetBrandingProfile(ctx context.Context, in *GetBrandingProfileRequest, opts ...grpc.CallOption) (*GetBrandingProfileResponse, error)
+	SetBrandingProfile(ctx context.Context, in *SetBrandingProfileRequest, opts ...grpc.CallOption) (*SetBrandingProfileResponse, error)
 	ListProposals(ctx context.Context, in *ListProposalsRequest, opts ...grpc.CallOption) (*ListProposalsResponse, error)
+	GetProposalRevisions(ctx context.Context, in *GetProposalRevisionsRequest, opts ...grpc.CallOption) (*GetProposalRevisionsResponse, error)
+	GetProposalRevision(ctx context.Context, in *GetProposalRevisionRequest, opts ...grpc.CallOption) (*GetProposalRevisionResponse, error)
+	RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error)
+	DiffProposalVersions(ctx context.Context, in *DiffProposalVersionsRequest, opts ...grpc.CallOption) (*DiffProposalVersionsResponse, error)
 }
 
 type proposalServiceClient struct {
@@ -97,6 +137,187 @@ func (c *proposalServiceClient) GetTemplatesForFreelancer(ctx context.Context, i
 	return out, nil
 }
 
+func (c *proposalServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTemplateResponse)
+	err := c.cc.Invoke(ctx, ProposalService_GetTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*UpdateTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTemplateResponse)
+	err := c.cc.Invoke(ctx, ProposalService_UpdateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, ProposalService_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) ListProposalsByTemplate(ctx context.Context, in *ListProposalsByTemplateRequest, opts ...grpc.CallOption) (*ListProposalsByTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProposalsByTemplateResponse)
+	err := c.cc.Invoke(ctx, ProposalService_ListProposalsByTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) GetTemplateStats(ctx context.Context, in *GetTemplateStatsRequest, opts ...grpc.CallOption) (*GetTemplateStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTemplateStatsResponse)
+	err := c.cc.Invoke(ctx, ProposalService_GetTemplateStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) AddProposalSection(ctx context.Context, in *AddProposalSectionRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProposalSectionsResponse)
+	err := c.cc.Invoke(ctx, ProposalService_AddProposalSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) UpdateProposalSection(ctx context.Context, in *UpdateProposalSectionRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProposalSectionsResponse)
+	err := c.cc.Invoke(ctx, ProposalService_UpdateProposalSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) ReorderProposalSections(ctx context.Context, in *ReorderProposalSectionsRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProposalSectionsResponse)
+	err := c.cc.Invoke(ctx, ProposalService_ReorderProposalSections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) RemoveProposalSection(ctx context.Context, in *RemoveProposalSectionRequest, opts ...grpc.CallOption) (*ProposalSectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProposalSectionsResponse)
+	err := c.cc.Invoke(ctx, ProposalService_RemoveProposalSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) SetMilestones(ctx context.Context, in *SetMilestonesRequest, opts ...grpc.CallOption) (*SetMilestonesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMilestonesResponse)
+	err := c.cc.Invoke(ctx, ProposalService_SetMilestones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProposalService_ServiceDesc.Streams[0], ProposalService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, UploadAttachmentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProposalService_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func (c *proposalServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProposalService_ServiceDesc.Streams[1], ProposalService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProposalService_DownloadAttachmentClient = grpc.ServerStreamingClient[DownloadAttachmentResponse]
+
+func (c *proposalServiceClient) RemoveAttachment(ctx context.Context, in *RemoveAttachmentRequest, opts ...grpc.CallOption) (*RemoveAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveAttachmentResponse)
+	err := c.cc.Invoke(ctx, ProposalService_RemoveAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) ExportProposal(ctx context.Context, in *ExportProposalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProposalResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProposalService_ServiceDesc.Streams[2], ProposalService_ExportProposal_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportProposalRequest, ExportProposalResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProposalService_ExportProposalClient = grpc.ServerStreamingClient[ExportProposalResponse]
+
+func (c *proposalServiceClient) GetBrandingProfile(ctx context.Context, in *GetBrandingProfileRequest, opts ...grpc.CallOption) (*GetBrandingProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBrandingProfileResponse)
+	err := c.cc.Invoke(ctx, ProposalService_GetBrandingProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) SetBrandingProfile(ctx context.Context, in *SetBrandingProfileRequest, opts ...grpc.CallOption) (*SetBrandingProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBrandingProfileResponse)
+	err := c.cc.Invoke(ctx, ProposalService_SetBrandingProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *proposalServiceClient) ListProposals(ctx context.Context, in *ListProposalsRequest, opts ...grpc.CallOption) (*ListProposalsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListProposalsResponse)
@@ -107,6 +328,46 @@ func (c *proposalServiceClient) ListProposals(ctx context.Context, in *ListPropo
 	return out, nil
 }
 
+func (c *proposalServiceClient) GetProposalRevisions(ctx context.Context, in *GetProposalRevisionsRequest, opts ...grpc.CallOption) (*GetProposalRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProposalRevisionsResponse)
+	err := c.cc.Invoke(ctx, ProposalService_GetProposalRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) GetProposalRevision(ctx context.Context, in *GetProposalRevisionRequest, opts ...grpc.CallOption) (*GetProposalRevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProposalRevisionResponse)
+	err := c.cc.Invoke(ctx, ProposalService_GetProposalRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreRevisionResponse)
+	err := c.cc.Invoke(ctx, ProposalService_RestoreRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proposalServiceClient) DiffProposalVersions(ctx context.Context, in *DiffProposalVersionsRequest, opts ...grpc.CallOption) (*DiffProposalVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffProposalVersionsResponse)
+	err := c.cc.Invoke(ctx, ProposalService_DiffProposalVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProposalServiceServer is the server API for ProposalService service.
 // All implementations must embed UnimplementedProposalServiceServer
 // for forward compatibility.
@@ -116,7 +377,27 @@ type ProposalServiceServer interface {
 	UpdateProposal(context.Context, *UpdateProposalRequest) (*UpdateProposalResponse, error)
 	SaveTemplate(context.Context, *SaveTemplateRequest) (*SaveTemplateResponse, error)
 	GetTemplatesForFreelancer(context.Context, *GetTemplatesRequest) (*GetTemplatesResponse, error)
+	GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error)
+	UpdateTemplate(context.Context, *UpdateTemplateRequest) (*UpdateTemplateResponse, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	ListProposalsByTemplate(context.Context, *ListProposalsByTemplateRequest) (*ListProposalsByTemplateResponse, error)
+	GetTemplateStats(context.Context, *GetTemplateStatsRequest) (*GetTemplateStatsResponse, error)
+	AddProposalSection(context.Context, *AddProposalSectionRequest) (*ProposalSectionsResponse, error)
+	UpdateProposalSection(context.Context, *UpdateProposalSectionRequest) (*ProposalSectionsResponse, error)
+	ReorderProposalSections(context.Context, *ReorderProposalSectionsRequest) (*ProposalSectionsResponse, error)
+	RemoveProposalSection(context.Context, *RemoveProposalSectionRequest) (*ProposalSectionsResponse, error)
+	SetMilestones(context.Context, *SetMilestonesRequest) (*SetMilestonesResponse, error)
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error
+	DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error
+	RemoveAttachment(context.Context, *RemoveAttachmentRequest) (*RemoveAttachmentResponse, error)
+	ExportProposal(*ExportProposalRequest, grpc.ServerStreamingServer[ExportProposalResponse]) error
+	GetBrandingProfile(context.Context, *GetBrandingProfileRequest) (*GetBrandingProfileResponse, error)
+	SetBrandingProfile(context.Context, *SetBrandingProfileRequest) (*SetBrandingProfileResponse, error)
 	ListProposals(context.Context, *ListProposalsRequest) (*ListProposalsResponse, error)
+	GetProposalRevisions(context.Context, *GetProposalRevisionsRequest) (*GetProposalRevisionsResponse, error)
+	GetProposalRevision(context.Context, *GetProposalRevisionRequest) (*GetProposalRevisionResponse, error)
+	RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error)
+	DiffProposalVersions(context.Context, *DiffProposalVersionsRequest) (*DiffProposalVersionsResponse, error)
 	mustEmbedUnimplementedProposalServiceServer()
 }
 
@@ -142,9 +423,69 @@ func (UnimplementedProposalServiceServer) SaveTemplate(context.Context, *SaveTem
 func (UnimplementedProposalServiceServer) GetTemplatesForFreelancer(context.Context, *GetTemplatesRequest) (*GetTemplatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTemplatesForFreelancer not implemented")
 }
+func (UnimplementedProposalServiceServer) GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedProposalServiceServer) UpdateTemplate(context.Context, *UpdateTemplateRequest) (*UpdateTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+func (UnimplementedProposalServiceServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedProposalServiceServer) ListProposalsByTemplate(context.Context, *ListProposalsByTemplateRequest) (*ListProposalsByTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProposalsByTemplate not implemented")
+}
+func (UnimplementedProposalServiceServer) GetTemplateStats(context.Context, *GetTemplateStatsRequest) (*GetTemplateStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplateStats not implemented")
+}
+func (UnimplementedProposalServiceServer) AddProposalSection(context.Context, *AddProposalSectionRequest) (*ProposalSectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProposalSection not implemented")
+}
+func (UnimplementedProposalServiceServer) UpdateProposalSection(context.Context, *UpdateProposalSectionRequest) (*ProposalSectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProposalSection not implemented")
+}
+func (UnimplementedProposalServiceServer) ReorderProposalSections(context.Context, *ReorderProposalSectionsRequest) (*ProposalSectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderProposalSections not implemented")
+}
+func (UnimplementedProposalServiceServer) RemoveProposalSection(context.Context, *RemoveProposalSectionRequest) (*ProposalSectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveProposalSection not implemented")
+}
+func (UnimplementedProposalServiceServer) SetMilestones(context.Context, *SetMilestonesRequest) (*SetMilestonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMilestones not implemented")
+}
+func (UnimplementedProposalServiceServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedProposalServiceServer) DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedProposalServiceServer) RemoveAttachment(context.Context, *RemoveAttachmentRequest) (*RemoveAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAttachment not implemented")
+}
+func (UnimplementedProposalServiceServer) ExportProposal(*ExportProposalRequest, grpc.ServerStreamingServer[ExportProposalResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProposal not implemented")
+}
+func (UnimplementedProposalServiceServer) GetBrandingProfile(context.Context, *GetBrandingProfileRequest) (*GetBrandingProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBrandingProfile not implemented")
+}
+func (UnimplementedProposalServiceServer) SetBrandingProfile(context.Context, *SetBrandingProfileRequest) (*SetBrandingProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBrandingProfile not implemented")
+}
 func (UnimplementedProposalServiceServer) ListProposals(context.Context, *ListProposalsRequest) (*ListProposalsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProposals not implemented")
 }
+func (UnimplementedProposalServiceServer) GetProposalRevisions(context.Context, *GetProposalRevisionsRequest) (*GetProposalRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProposalRevisions not implemented")
+}
+func (UnimplementedProposalServiceServer) GetProposalRevision(context.Context, *GetProposalRevisionRequest) (*GetProposalRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProposalRevision not implemented")
+}
+func (UnimplementedProposalServiceServer) RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedProposalServiceServer) DiffProposalVersions(context.Context, *DiffProposalVersionsRequest) (*DiffProposalVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffProposalVersions not implemented")
+}
 func (UnimplementedProposalServiceServer) mustEmbedUnimplementedProposalServiceServer() {}
 func (UnimplementedProposalServiceServer) testEmbeddedByValue()                         {}
 
@@ -256,6 +597,269 @@ func _ProposalService_GetTemplatesForFreelancer_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProposalService_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).GetTemplate(ctx, req.(*GetTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_UpdateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).UpdateTemplate(ctx, req.(*UpdateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_ListProposalsByTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProposalsByTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).ListProposalsByTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_ListProposalsByTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).ListProposalsByTemplate(ctx, req.(*ListProposalsByTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_GetTemplateStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).GetTemplateStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_GetTemplateStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).GetTemplateStats(ctx, req.(*GetTemplateStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_AddProposalSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProposalSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).AddProposalSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_AddProposalSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).AddProposalSection(ctx, req.(*AddProposalSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_UpdateProposalSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProposalSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).UpdateProposalSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_UpdateProposalSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).UpdateProposalSection(ctx, req.(*UpdateProposalSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_ReorderProposalSections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderProposalSectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).ReorderProposalSections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_ReorderProposalSections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).ReorderProposalSections(ctx, req.(*ReorderProposalSectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_RemoveProposalSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveProposalSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).RemoveProposalSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_RemoveProposalSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).RemoveProposalSection(ctx, req.(*RemoveProposalSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_SetMilestones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMilestonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).SetMilestones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_SetMilestones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).SetMilestones(ctx, req.(*SetMilestonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProposalServiceServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, UploadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProposalService_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func _ProposalService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProposalServiceServer).DownloadAttachment(m, &grpc.GenericServerStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProposalService_DownloadAttachmentServer = grpc.ServerStreamingServer[DownloadAttachmentResponse]
+
+func _ProposalService_RemoveAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).RemoveAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_RemoveAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).RemoveAttachment(ctx, req.(*RemoveAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_ExportProposal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProposalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProposalServiceServer).ExportProposal(m, &grpc.GenericServerStream[ExportProposalRequest, ExportProposalResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProposalService_ExportProposalServer = grpc.ServerStreamingServer[ExportProposalResponse]
+
+func _ProposalService_GetBrandingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBrandingProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).GetBrandingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_GetBrandingProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).GetBrandingProfile(ctx, req.(*GetBrandingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_SetBrandingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBrandingProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).SetBrandingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_SetBrandingProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).SetBrandingProfile(ctx, req.(*SetBrandingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProposalService_ListProposals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListProposalsRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +878,78 @@ func _ProposalService_ListProposals_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProposalService_GetProposalRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProposalRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).GetProposalRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_GetProposalRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).GetProposalRevisions(ctx, req.(*GetProposalRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_GetProposalRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProposalRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).GetProposalRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_GetProposalRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).GetProposalRevision(ctx, req.(*GetProposalRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_RestoreRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).RestoreRevision(ctx, req.(*RestoreRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProposalService_DiffProposalVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffProposalVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProposalServiceServer).DiffProposalVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProposalService_DiffProposalVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProposalServiceServer).DiffProposalVersions(ctx, req.(*DiffProposalVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProposalService_ServiceDesc is the grpc.ServiceDesc for ProposalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -301,11 +977,95 @@ var ProposalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTemplatesForFreelancer",
 			Handler:    _ProposalService_GetTemplatesForFreelancer_Handler,
 		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _ProposalService_GetTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _ProposalService_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _ProposalService_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "ListProposalsByTemplate",
+			Handler:    _ProposalService_ListProposalsByTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplateStats",
+			Handler:    _ProposalService_GetTemplateStats_Handler,
+		},
+		{
+			MethodName: "AddProposalSection",
+			Handler:    _ProposalService_AddProposalSection_Handler,
+		},
+		{
+			MethodName: "UpdateProposalSection",
+			Handler:    _ProposalService_UpdateProposalSection_Handler,
+		},
+		{
+			MethodName: "ReorderProposalSections",
+			Handler:    _ProposalService_ReorderProposalSections_Handler,
+		},
+		{
+			MethodName: "RemoveProposalSection",
+			Handler:    _ProposalService_RemoveProposalSection_Handler,
+		},
+		{
+			MethodName: "SetMilestones",
+			Handler:    _ProposalService_SetMilestones_Handler,
+		},
+		{
+			MethodName: "RemoveAttachment",
+			Handler:    _ProposalService_RemoveAttachment_Handler,
+		},
+		{
+			MethodName: "GetBrandingProfile",
+			Handler:    _ProposalService_GetBrandingProfile_Handler,
+		},
+		{
+			MethodName: "SetBrandingProfile",
+			Handler:    _ProposalService_SetBrandingProfile_Handler,
+		},
 		{
 			MethodName: "ListProposals",
 			Handler:    _ProposalService_ListProposals_Handler,
 		},
+		{
+			MethodName: "GetProposalRevisions",
+			Handler:    _ProposalService_GetProposalRevisions_Handler,
+		},
+		{
+			MethodName: "GetProposalRevision",
+			Handler:    _ProposalService_GetProposalRevision_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _ProposalService_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "DiffProposalVersions",
+			Handler:    _ProposalService_DiffProposalVersions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _ProposalService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _ProposalService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportProposal",
+			Handler:       _ProposalService_ExportProposal_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proposal.proto",
 }